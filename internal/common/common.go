@@ -22,12 +22,36 @@ import (
 	"os"
 	"path"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
 )
 
 var (
 	DataPathPrefix = ""
+
+	fileCache      = map[string]cachedFile{}
+	fileCacheMutex sync.Mutex
+	fileCacheTTL   = time.Minute * 5
+
+	// the number of attempts made to read stable.txt before falling back
+	// to the last known good value
+	stableTxtMaxAttempts = 3
+	// the delay between stable.txt read attempts
+	stableTxtRetryBackoff = time.Millisecond * 200
+	// the most recently read stable.txt value, served with a warning when
+	// a fresh read fails entirely
+	stableTxtLastKnownGood string
+	stableTxtMutex         sync.Mutex
 )
 
+type cachedFile struct {
+	content string
+	modTime time.Time
+	readAt  time.Time
+}
+
 func Pointer[V any](input V) *V {
 	return &input
 }
@@ -44,6 +68,32 @@ func SafeError(input error) (output error) {
 	return fmt.Errorf(html.EscapeString(input.Error()))
 }
 
+// ReadFileCached returns the content of the file at path, re-reading it only
+// when the cache entry has expired or the file's modification time has changed
+func ReadFileCached(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return ReadFile(path)
+	}
+	fileCacheMutex.Lock()
+	defer fileCacheMutex.Unlock()
+	if cached, ok := fileCache[path]; ok &&
+		cached.modTime.Equal(info.ModTime()) &&
+		time.Since(cached.readAt) < fileCacheTTL {
+		return cached.content, nil
+	}
+	content, err := ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	fileCache[path] = cachedFile{
+		content: content,
+		modTime: info.ModTime(),
+		readAt:  time.Now(),
+	}
+	return content, nil
+}
+
 func GetDataPath() string {
 	if val, ok := os.LookupEnv("KO_DATA_PATH"); ok {
 		return val
@@ -51,10 +101,32 @@ func GetDataPath() string {
 	return path.Join(DataPathPrefix, "./kodata")
 }
 
+// GetStableTxt returns the latest stable Kubernetes version. Reads are
+// retried briefly, and when they fail entirely the last successfully read
+// value is served with a warning so a transient failure doesn't abort the
+// handling of a PR
 func GetStableTxt() (string, error) {
-	content, err := ReadFile(path.Join(GetDataPath(), "metadata", "stable.txt"))
-	if err != nil {
-		return "", err
+	var lastErr error
+	for attempt := 1; attempt <= stableTxtMaxAttempts; attempt++ {
+		content, err := ReadFileCached(path.Join(GetDataPath(), "metadata", "stable.txt"))
+		if err == nil {
+			version := strings.TrimSuffix(content, "\n")
+			stableTxtMutex.Lock()
+			stableTxtLastKnownGood = version
+			stableTxtMutex.Unlock()
+			return version, nil
+		}
+		lastErr = err
+		if attempt < stableTxtMaxAttempts {
+			time.Sleep(stableTxtRetryBackoff)
+		}
+	}
+	stableTxtMutex.Lock()
+	fallback := stableTxtLastKnownGood
+	stableTxtMutex.Unlock()
+	if fallback != "" {
+		logrus.Warnf("unable to read stable.txt (%v); using the last known good value %v", lastErr, fallback)
+		return fallback, nil
 	}
-	return strings.TrimSuffix(content, "\n"), nil
+	return "", lastErr
 }
@@ -24,6 +24,7 @@ import (
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 )
 
 func init() {
@@ -83,6 +84,56 @@ func TestReadFile(t *testing.T) {
 	}
 }
 
+func TestReadFileCached(t *testing.T) {
+	filePath := t.TempDir() + "/stable.txt"
+	if err := os.WriteFile(filePath, []byte("v1.30.0\n"), 0644); err != nil {
+		t.Fatalf("error: writing file; %v", err)
+	}
+	content, err := ReadFileCached(filePath)
+	if err != nil {
+		t.Fatalf("error: reading file; %v", err)
+	}
+	if content != "v1.30.0\n" {
+		t.Fatalf("error: file content does not match expected")
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		t.Fatalf("error: stating file; %v", err)
+	}
+
+	// an unchanged mtime must serve the cached content without another read
+	if err := os.WriteFile(filePath, []byte("v1.31.0\n"), 0644); err != nil {
+		t.Fatalf("error: writing file; %v", err)
+	}
+	if err := os.Chtimes(filePath, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatalf("error: restoring file times; %v", err)
+	}
+	content, err = ReadFileCached(filePath)
+	if err != nil {
+		t.Fatalf("error: reading file; %v", err)
+	}
+	if content != "v1.30.0\n" {
+		t.Fatalf("error: expected the cached content, got '%v'", content)
+	}
+
+	// a new mtime must invalidate the cache entry
+	newTime := info.ModTime().Add(time.Second)
+	if err := os.Chtimes(filePath, newTime, newTime); err != nil {
+		t.Fatalf("error: updating file times; %v", err)
+	}
+	content, err = ReadFileCached(filePath)
+	if err != nil {
+		t.Fatalf("error: reading file; %v", err)
+	}
+	if content != "v1.31.0\n" {
+		t.Fatalf("error: expected the updated content, got '%v'", content)
+	}
+
+	if _, err := ReadFileCached("./testdata/non-existent-file.txt"); err == nil {
+		t.Fatalf("error: file should not exist")
+	}
+}
+
 func TestSafeError(t *testing.T) {
 	inputText := "<p>Hello</p>"
 	expectedText := `&lt;p&gt;Hello&lt;/p&gt;`
@@ -111,8 +162,41 @@ func TestGetStableTxt(t *testing.T) {
 	if err := os.Setenv("KO_DATA_PATH", "./../../kodat"); err != nil {
 		t.Fatalf("failed to set env: %v", err)
 	}
-	_, err = GetStableTxt()
-	if err == nil {
+	defer func() {
+		if err := os.Setenv("KO_DATA_PATH", "./../../kodata"); err != nil {
+			t.Fatalf("failed to restore env: %v", err)
+		}
+	}()
+	// the successful read above serves as the last known good value
+	fallback, err := GetStableTxt()
+	if err != nil {
+		t.Fatalf("error: expected a fallback to the last known good value; %v", err)
+	}
+	if fallback != version {
+		t.Fatalf("error: fallback version (%v) doesn't match the last read version (%v)", fallback, version)
+	}
+}
+
+func TestGetStableTxtFailsWithoutLastKnownGood(t *testing.T) {
+	defaultBackoff := stableTxtRetryBackoff
+	defaultLastKnownGood := stableTxtLastKnownGood
+	defer func() {
+		stableTxtRetryBackoff = defaultBackoff
+		stableTxtLastKnownGood = defaultLastKnownGood
+	}()
+	stableTxtRetryBackoff = time.Millisecond
+	stableTxtLastKnownGood = ""
+
+	checkpointValue := os.Getenv("KO_DATA_PATH")
+	if err := os.Setenv("KO_DATA_PATH", "./../../kodat"); err != nil {
+		t.Fatalf("failed to set env: %v", err)
+	}
+	defer func() {
+		if err := os.Setenv("KO_DATA_PATH", checkpointValue); err != nil {
+			t.Fatalf("failed to restore env: %v", err)
+		}
+	}()
+	if _, err := GetStableTxt(); err == nil {
 		t.Fatalf("error expected to not find stable.txt")
 	}
 }
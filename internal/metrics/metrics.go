@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	// PRsHandledTotal counts handled PRs by result (success or error)
+	PRsHandledTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "verifyconformance_prs_handled_total",
+		Help: "Number of PRs handled, partitioned by result.",
+	}, []string{"result"})
+	// LabelsAddedTotal counts labels added to PRs
+	LabelsAddedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "verifyconformance_labels_added_total",
+		Help: "Number of labels added to PRs.",
+	})
+	// HandleDuration observes how long handling a single PR takes
+	HandleDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "verifyconformance_handle_duration_seconds",
+		Help:    "Duration of handling a single PR in seconds.",
+		Buckets: prometheus.ExponentialBuckets(0.1, 2, 12),
+	})
+)
+
+func init() {
+	prometheus.MustRegister(PRsHandledTotal, LabelsAddedTotal, HandleDuration)
+}
+
+// Handler returns an http.Handler that serves the registered metrics
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func gatherMetricFamily(t *testing.T, name string) *dto.MetricFamily {
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("error: gathering metrics; %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() == name {
+			return family
+		}
+	}
+	return nil
+}
+
+func TestMetricsAreRegisteredAndMove(t *testing.T) {
+	PRsHandledTotal.WithLabelValues("success").Inc()
+	LabelsAddedTotal.Add(3)
+	HandleDuration.Observe(1.5)
+
+	family := gatherMetricFamily(t, "verifyconformance_prs_handled_total")
+	if family == nil {
+		t.Fatalf("error: metric verifyconformance_prs_handled_total is not registered")
+	}
+	found := false
+	for _, metric := range family.GetMetric() {
+		for _, label := range metric.GetLabel() {
+			if label.GetName() == "result" && label.GetValue() == "success" && metric.GetCounter().GetValue() >= 1 {
+				found = true
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("error: expected verifyconformance_prs_handled_total{result=\"success\"} to move")
+	}
+
+	family = gatherMetricFamily(t, "verifyconformance_labels_added_total")
+	if family == nil {
+		t.Fatalf("error: metric verifyconformance_labels_added_total is not registered")
+	}
+	if family.GetMetric()[0].GetCounter().GetValue() < 3 {
+		t.Fatalf("error: expected verifyconformance_labels_added_total to move")
+	}
+
+	family = gatherMetricFamily(t, "verifyconformance_handle_duration_seconds")
+	if family == nil {
+		t.Fatalf("error: metric verifyconformance_handle_duration_seconds is not registered")
+	}
+	if family.GetMetric()[0].GetHistogram().GetSampleCount() < 1 {
+		t.Fatalf("error: expected verifyconformance_handle_duration_seconds to move")
+	}
+}
@@ -18,15 +18,26 @@ package plugin
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	githubql "github.com/shurcooL/githubv4"
@@ -38,11 +49,34 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"sigs.k8s.io/verify-conformance/internal/common"
+	"sigs.k8s.io/verify-conformance/internal/metrics"
 	"sigs.k8s.io/verify-conformance/internal/suite"
 )
 
 const (
 	PluginName = "verify-conformance"
+
+	// the issue comment command that triggers a full re-evaluation of a PR
+	recheckCommand = "/verify-conformance recheck"
+
+	// the issue comment command that restores any managed labels missing from
+	// a PR using the label set computed on the last run, without re-fetching
+	// artifacts or re-running the suite
+	relabelCommand = "/verify-conformance relabel"
+	// the issue comment command that removes every label the plugin manages
+	// from a PR so the next run rebuilds them cleanly; restricted to org
+	// members
+	resetLabelsCommand = "/verify-conformance reset-labels"
+	// the issue comment command that re-posts the result comment rendered on
+	// the last run, without re-fetching artifacts or re-running the suite
+	statusCommand = "/verify-conformance status"
+	// the issue comment command, taking an advisory check name as its
+	// argument, that records a maintainer override so subsequent runs treat
+	// that advisory as satisfied; restricted to org members
+	overrideCommand = "/verify-conformance override"
+	// marks a PR as having received a stale submission reminder, so the
+	// nudge is only sent once
+	staleReminderLabel = "stale-reminder-sent"
 )
 
 var (
@@ -50,6 +84,7 @@ var (
 		{Field: "website_url"},
 		{Field: "repo_url"},
 		{Field: "documentation_url"},
+		{Field: "product_logo_url"},
 	}
 	managedPRLabels = []string{
 		"conformance-product-submission",
@@ -57,23 +92,645 @@ var (
 		"not-verifiable",
 		"release-documents-checked",
 		"required-tests-missing",
+		"failed-tests-present",
 		"evidence-missing",
+		"missing-product-fields",
+		"product-yaml-unexpected-fields",
+		"version-mismatch",
+		"invalid-product-logo",
+		"eol-version",
 		"unable-to-process",
+		"release-not-yet-available",
+		"release-candidate",
+		"acknowledgment-missing",
+		"tampered-evidence",
+		"wrong-base-branch",
 	}
 	managedPRLabelTemplatesWithVersion = []string{
 		"release-%v",
 		"no-failed-tests-%v",
 		"tests-verified-%v",
 	}
-	managedPRLabelTemplatesWithFileName = []string{"missing-file-%v"}
+	managedPRLabelTemplatesWithFileName = []string{"missing-file-%v", "oversized-file-%v"}
+	// labels that mark a submission as currently failing verification,
+	// considered when looking for stale submissions
+	failingPRLabels = []string{
+		"not-verifiable",
+		"required-tests-missing",
+		"failed-tests-present",
+		"evidence-missing",
+		"missing-product-fields",
+		"version-mismatch",
+		"invalid-product-logo",
+		"eol-version",
+		"unable-to-process",
+		"acknowledgment-missing",
+		"tampered-evidence",
+	}
 	// TODO swap out for ldflag to override variable if built with Ko
 	godogPaths = []string{"./features/", "./kodata/features/", "/var/run/ko/features/", "../../kodata/features/"}
+	// controls whether TLS certificates are verified when resolving the URL
+	// fields of PRODUCT.yaml; only skipped when explicitly opted in
+	productYAMLURLChecksInsecureSkipTLSVerify = false
+	// the maximum number of PRs processed in parallel during HandleAll
+	handleAllConcurrency = 4
+	// delay inserted between dispatching PRs during a scan and between the
+	// PRODUCT.yaml URL checks of a submission, so a burst of GitHub API
+	// calls doesn't trip secondary rate limits; zero disables staggering
+	handleAllStagger = time.Duration(0)
+	// indirection over time.Sleep so staggering can be tested against a
+	// fake clock
+	sleep = time.Sleep
+	// the deadline for a single periodic scan of all PRs; PRs not handled
+	// before it are skipped until the next scan
+	handleAllTimeout = time.Hour
+	// pagination during search pauses until the rate limit resets when
+	// fewer GraphQL points than this remain
+	searchRateLimitThreshold = 100
+	// the number of attempts made for each search page before a transient
+	// error is surfaced as a hard one
+	searchMaxAttempts = 4
+	// the initial delay between search attempts, doubled on each retry
+	// unless the error carries a Retry-After hint
+	searchRetryBackoff = time.Second * 30
+	// extracts the wait requested by a Retry-After hint embedded in an error
+	searchRetryAfterPattern = regexp.MustCompile(`retry.after[:= ]+([0-9]+)`)
+	// when set, outbound fetches traverse this proxy instead of the one
+	// configured in the process environment
+	outboundProxyURL *url.URL
+	// the base URL of the GitHub GraphQL API; set for enterprise deployments
+	// so repos configured with a matching host prefix are scoped to the
+	// right org; nil assumes the public API
+	githubGraphQLEndpoint *url.URL
+	// performs the outbound fetches of PR files and the product logo;
+	// replaceable to route requests through a custom transport or to stub
+	// them out in tests
+	// redirects are subject to the same per-hop host policy as the
+	// PRODUCT.yaml URL checks, so a fetched file can't bounce the plugin to a
+	// non-public address
+	fetchClient HTTPDoer = &http.Client{Transport: &http.Transport{Proxy: outboundProxy}, CheckRedirect: checkURLRedirect}
+	// when set, resolves the URL fields of PRODUCT.yaml in place of the
+	// per-call client built from the configured timeout and TLS settings
+	productYAMLURLClient HTTPDoer
+	// the number of attempts made to fetch a PR file before giving up;
+	// only 5xx responses and network errors are retried
+	fetchFileMaxAttempts = 3
+	// the initial delay between fetch attempts, doubled on each retry
+	fetchFileRetryBackoff = time.Second
+	// how long to wait when resolving a PRODUCT.yaml URL field before
+	// recording an empty content-type and moving on
+	productYAMLURLCheckTimeout = time.Second * 10
+	// the longest redirect chain followed when resolving a PRODUCT.yaml URL
+	// field
+	maxURLRedirects = 10
+	// returned by checkURLRedirect when a redirect chain revisits a URL
+	errRedirectLoop = errors.New("the URL redirects in a loop")
+	// returned by checkURLRedirect when a redirect chain exceeds
+	// maxURLRedirects
+	errTooManyRedirects = errors.New("the URL redirects too many times")
+	// certificate roots trusted when verifying the TLS connections of
+	// PRODUCT.yaml URL checks; nil uses the system roots alone
+	productYAMLURLRootCAs *x509.CertPool
+	// hosts that URL fields of PRODUCT.yaml may name; when non-empty, hosts
+	// off the list are refused and listed hosts skip the address checks
+	productYAMLURLAllowedHosts = []string{}
+	// hosts that URL fields of PRODUCT.yaml must never name
+	productYAMLURLDeniedHosts = []string{}
+	// controls whether suite results are reported through a GitHub Checks
+	// run with per-requirement annotations; requires the checks:write
+	// permission on the GitHub App
+	checksRunEnabled = false
+	// controls whether a machine-readable JSON summary of the suite results
+	// is attached to the comment in a collapsed details block
+	attachJSONResults = false
+	// appended below a horizontal rule to every comment the plugin posts,
+	// e.g. maintainer contact details or documentation links
+	commentFooter = ""
+	// appended to the result comment when every requirement passes, e.g.
+	// congratulating the submitter and outlining the review and merge steps
+	// that follow
+	successComment = ""
+	// limits suite runs to scenarios matching this godog tag expression,
+	// e.g. '@product-yaml'; empty runs every scenario
+	featureTagFilter = ""
+	// controls whether earlier bot comments are kept when a new result is
+	// posted; by default stale comments are pruned so only the latest
+	// result remains
+	retainCommentHistory = false
+	// when set, the PR description must contain this phrase before checks
+	// run, e.g. an agreement to the conformance terms; matched
+	// case-insensitively
+	requiredAcknowledgment = ""
+	// when set, submissions must target this base branch; PRs opened against
+	// another branch receive an advisory and the wrong-base-branch label
+	expectedBaseBranch = ""
+	// per-org settings taking precedence over the matching global
+	// configuration, keyed by org login
+	orgOverrides = map[string]OrgOverrides{}
+	// submissions that have carried a failing label for longer than this
+	// receive a single reminder comment during the periodic scan
+	staleSubmissionAge = time.Hour * 24 * 14
+	// recognises conformance submission titles; the optional named groups
+	// 'version' and 'product' are extracted into the match result
+	conformancePRTitlePattern = regexp.MustCompile(`(?i)conformance\s+(?:test\s+)?results?\s*(?:for|:)?\s*(?P<version>v[0-9]+\.[0-9]+)?[\s/]*(?P<product>.*)`)
+	// finds a release version anywhere in a PR title, with or without a 'v'
+	// prefix or a patch suffix
+	titleVersionExtractPattern = regexp.MustCompile(`\bv?([0-9]+)\.([0-9]+)(?:\.[0-9]+)?\b`)
+
+	// controls whether intended mutations are only logged rather than
+	// applied to the PR
+	dryRun = false
+
+	// the label set most recently computed for each PR head, keyed by
+	// org/repo!number@sha; consulted by the relabel command so managed labels
+	// can be restored without re-running the suite
+	computedLabelsMutex sync.Mutex
+	computedLabels      = map[string][]string{}
+	// the result comment most recently rendered for each PR head, under the
+	// same key; consulted by the status command so the latest result can be
+	// re-posted without re-running the suite
+	computedComments = map[string]string{}
+	// the status state most recently computed for each PR head, under the
+	// same key; a head recorded as verified successfully isn't re-run by
+	// later scans until it changes or a recheck is requested
+	computedStates = map[string]string{}
+	// the head most recently recorded for each PR, keyed by org/repo!number;
+	// when a PR's head moves on, the results cached for its previous head are
+	// dropped so the caches stay bounded by the number of PRs seen rather
+	// than growing with every push
+	computedHeads = map[string]string{}
 )
 
+// computedLabelsKey returns the cache key for the label set computed for the
+// head commit of a PR.
+func computedLabelsKey(pr *suite.PullRequestQuery) string {
+	return fmt.Sprintf("%v/%v!%v@%v", pr.Repository.Owner.Login, pr.Repository.Name, pr.Number, pr.HeadRefOID)
+}
+
+// computedResultsPRKey returns the per-PR portion of the cache key, without
+// the head commit.
+func computedResultsPRKey(pr *suite.PullRequestQuery) string {
+	return fmt.Sprintf("%v/%v!%v", pr.Repository.Owner.Login, pr.Repository.Name, pr.Number)
+}
+
+// evictStaleComputedResultsLocked drops the results recorded for the previous
+// head of a PR once a new head is seen; entries for orphaned heads are never
+// consulted again. Callers must hold computedLabelsMutex.
+func evictStaleComputedResultsLocked(pr *suite.PullRequestQuery) {
+	prKey := computedResultsPRKey(pr)
+	head := string(pr.HeadRefOID)
+	if previous, ok := computedHeads[prKey]; ok && previous != head {
+		staleKey := prKey + "@" + previous
+		delete(computedLabels, staleKey)
+		delete(computedComments, staleKey)
+		delete(computedStates, staleKey)
+	}
+	computedHeads[prKey] = head
+}
+
+// storeComputedLabels records the label set computed for the head commit of a
+// PR.
+func storeComputedLabels(pr *suite.PullRequestQuery, labels []string) {
+	computedLabelsMutex.Lock()
+	defer computedLabelsMutex.Unlock()
+	evictStaleComputedResultsLocked(pr)
+	computedLabels[computedLabelsKey(pr)] = append([]string{}, labels...)
+}
+
+// computedLabelsForPullRequest returns the label set computed for the head
+// commit of a PR, if one has been recorded.
+func computedLabelsForPullRequest(pr *suite.PullRequestQuery) ([]string, bool) {
+	computedLabelsMutex.Lock()
+	defer computedLabelsMutex.Unlock()
+	labels, ok := computedLabels[computedLabelsKey(pr)]
+	return labels, ok
+}
+
+// storeComputedComment records the result comment rendered for the head
+// commit of a PR.
+func storeComputedComment(pr *suite.PullRequestQuery, comment string) {
+	computedLabelsMutex.Lock()
+	defer computedLabelsMutex.Unlock()
+	evictStaleComputedResultsLocked(pr)
+	computedComments[computedLabelsKey(pr)] = comment
+}
+
+// computedCommentForPullRequest returns the result comment rendered for the
+// head commit of a PR, if one has been recorded.
+func computedCommentForPullRequest(pr *suite.PullRequestQuery) (string, bool) {
+	computedLabelsMutex.Lock()
+	defer computedLabelsMutex.Unlock()
+	comment, ok := computedComments[computedLabelsKey(pr)]
+	return comment, ok
+}
+
+// storeComputedState records the status state computed for the head commit of
+// a PR.
+func storeComputedState(pr *suite.PullRequestQuery, state string) {
+	computedLabelsMutex.Lock()
+	defer computedLabelsMutex.Unlock()
+	evictStaleComputedResultsLocked(pr)
+	computedStates[computedLabelsKey(pr)] = state
+}
+
+// computedStateForPullRequest returns the status state computed for the head
+// commit of a PR, if one has been recorded.
+func computedStateForPullRequest(pr *suite.PullRequestQuery) (string, bool) {
+	computedLabelsMutex.Lock()
+	defer computedLabelsMutex.Unlock()
+	state, ok := computedStates[computedLabelsKey(pr)]
+	return state, ok
+}
+
+// invalidateComputedResults drops the results recorded for the head commit of
+// a PR, forcing the next handling to run the suite in full.
+func invalidateComputedResults(pr *suite.PullRequestQuery) {
+	computedLabelsMutex.Lock()
+	defer computedLabelsMutex.Unlock()
+	delete(computedLabels, computedLabelsKey(pr))
+	delete(computedComments, computedLabelsKey(pr))
+	delete(computedStates, computedLabelsKey(pr))
+	delete(computedHeads, computedResultsPRKey(pr))
+}
+
+// SetProductYAMLURLHostPolicy configures which hosts the URL fields of
+// PRODUCT.yaml may name. Denied hosts are always refused. When the allowed
+// list is non-empty, hosts off it are refused and hosts on it skip the
+// non-public address checks.
+func SetProductYAMLURLHostPolicy(allowed []string, denied []string) {
+	productYAMLURLAllowedHosts = append([]string{}, allowed...)
+	productYAMLURLDeniedHosts = append([]string{}, denied...)
+}
+
+// productYAMLURLBlockedReason reports why a host named by an untrusted
+// PRODUCT.yaml URL field must not be dereferenced, or an empty string when it
+// may be. Hosts resolving to loopback, private, or link-local addresses
+// (which include cloud metadata endpoints) are refused so a submission cannot
+// probe the network the plugin runs on.
+func productYAMLURLBlockedReason(host string) string {
+	if host == "" {
+		return "the URL has no host"
+	}
+	hostLower := strings.ToLower(host)
+	for _, denied := range productYAMLURLDeniedHosts {
+		if hostLower == strings.ToLower(denied) {
+			return fmt.Sprintf("host '%v' is on the denylist", host)
+		}
+	}
+	if len(productYAMLURLAllowedHosts) > 0 {
+		for _, allowed := range productYAMLURLAllowedHosts {
+			if hostLower == strings.ToLower(allowed) {
+				return ""
+			}
+		}
+		return fmt.Sprintf("host '%v' is not on the allowlist", host)
+	}
+	ips := []net.IP{}
+	if ip := net.ParseIP(host); ip != nil {
+		ips = append(ips, ip)
+	} else {
+		resolved, err := net.LookupIP(host)
+		if err != nil {
+			return fmt.Sprintf("unable to resolve host '%v', %v", host, err)
+		}
+		ips = resolved
+	}
+	for _, ip := range ips {
+		if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+			return fmt.Sprintf("host '%v' resolves to the non-public address %v", host, ip)
+		}
+	}
+	return ""
+}
+
+// SetProductYAMLURLCABundle loads a PEM bundle of additional certificate
+// authorities trusted when verifying the TLS connections of PRODUCT.yaml URL
+// checks, layered on top of the system roots, e.g. for vendors serving from
+// an internal CA. An empty path leaves the system roots alone.
+func SetProductYAMLURLCABundle(bundlePath string) error {
+	if bundlePath == "" {
+		return nil
+	}
+	bundle, err := os.ReadFile(bundlePath)
+	if err != nil {
+		return fmt.Errorf("unable to read the CA bundle '%v', %v", bundlePath, err)
+	}
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	if !roots.AppendCertsFromPEM(bundle) {
+		return fmt.Errorf("unable to parse any certificates from the CA bundle '%v'", bundlePath)
+	}
+	productYAMLURLRootCAs = roots
+	return nil
+}
+
+// checkURLRedirect bounds the redirect chains followed when resolving a
+// PRODUCT.yaml URL field, refusing chains that revisit a URL, grow longer
+// than maxURLRedirects, or hop to a host the URL host policy refuses — the
+// initially submitted host passing the policy says nothing about where it
+// redirects to
+func checkURLRedirect(req *http.Request, via []*http.Request) error {
+	if reason := productYAMLURLBlockedReason(req.URL.Hostname()); reason != "" {
+		return fmt.Errorf("refusing to follow a redirect to '%v'; %v", req.URL, reason)
+	}
+	for _, earlier := range via {
+		if earlier.URL.String() == req.URL.String() {
+			return fmt.Errorf("%w, revisiting %v", errRedirectLoop, req.URL)
+		}
+	}
+	if len(via) >= maxURLRedirects {
+		return fmt.Errorf("%w, stopping after %v", errTooManyRedirects, maxURLRedirects)
+	}
+	return nil
+}
+
+// SetProductYAMLURLCheckTimeout configures how long to wait when resolving
+// the URL fields of PRODUCT.yaml.
+func SetProductYAMLURLCheckTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	productYAMLURLCheckTimeout = timeout
+}
+
+// SetFetchFileMaxAttempts configures how many times fetching a PR file is
+// attempted before giving up.
+func SetFetchFileMaxAttempts(attempts int) {
+	if attempts < 1 {
+		attempts = 1
+	}
+	fetchFileMaxAttempts = attempts
+}
+
+// SetHandleAllConcurrency configures how many PRs are processed in parallel
+// during periodic scans of all PRs.
+func SetHandleAllConcurrency(concurrency int) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	handleAllConcurrency = concurrency
+}
+
+// SetHandleAllStagger configures the delay inserted between dispatching PRs
+// during periodic scans and between PRODUCT.yaml URL checks.
+func SetHandleAllStagger(stagger time.Duration) {
+	if stagger < 0 {
+		return
+	}
+	handleAllStagger = stagger
+}
+
+// SetSearchRateLimitThreshold configures how many GraphQL rate limit points
+// must remain for search pagination to continue without pausing.
+func SetSearchRateLimitThreshold(points int) {
+	if points < 0 {
+		return
+	}
+	searchRateLimitThreshold = points
+}
+
+// SetHandleAllTimeout configures the deadline for a single periodic scan of
+// all PRs.
+func SetHandleAllTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	handleAllTimeout = timeout
+}
+
+// SetProductYAMLURLChecksInsecureSkipTLSVerify configures whether TLS
+// certificate verification is skipped when resolving PRODUCT.yaml URL fields.
+// Skipping verification is intended for testing only.
+func SetProductYAMLURLChecksInsecureSkipTLSVerify(skip bool) {
+	productYAMLURLChecksInsecureSkipTLSVerify = skip
+	if skip {
+		logrus.Warn("TLS certificate verification for PRODUCT.yaml URL checks is disabled")
+	}
+}
+
+// SetManagedLabels configures the label names and templates that the plugin
+// manages on PRs, for deployments against repos that use different label
+// names. Nil or empty slices leave the current values in place. Templates
+// must contain a '%v' placeholder for the release version or file name.
+func SetManagedLabels(labels, versionLabelTemplates, fileLabelTemplates []string) error {
+	for _, l := range labels {
+		if strings.TrimSpace(l) == "" {
+			return fmt.Errorf("managed label names must not be empty")
+		}
+	}
+	for _, t := range append(append([]string{}, versionLabelTemplates...), fileLabelTemplates...) {
+		if !strings.Contains(t, "%v") {
+			return fmt.Errorf("managed label template '%v' must contain a '%%v' placeholder", t)
+		}
+	}
+	if len(labels) > 0 {
+		managedPRLabels = labels
+	}
+	if len(versionLabelTemplates) > 0 {
+		managedPRLabelTemplatesWithVersion = versionLabelTemplates
+	}
+	if len(fileLabelTemplates) > 0 {
+		managedPRLabelTemplatesWithFileName = fileLabelTemplates
+	}
+	return nil
+}
+
+// SetAttachJSONResults configures whether a machine-readable JSON summary of
+// the suite results is attached to the comment in a collapsed details block.
+func SetAttachJSONResults(enabled bool) {
+	attachJSONResults = enabled
+}
+
+// SetCommentFooter configures a footer appended below a horizontal rule to
+// every comment the plugin posts, e.g. maintainer contact details or
+// documentation links. An empty footer appends nothing.
+func SetCommentFooter(footer string) {
+	commentFooter = strings.TrimSpace(footer)
+}
+
+// SetFeatureTagFilter limits suite runs to scenarios matching the given godog
+// tag expression, e.g. '@product-yaml'. An empty expression runs every
+// scenario.
+func SetFeatureTagFilter(tags string) {
+	featureTagFilter = strings.TrimSpace(tags)
+}
+
+// GetFeatureTagFilter returns the godog tag expression limiting suite runs,
+// falling back to the VERIFY_CONFORMANCE_FEATURE_TAGS environment variable
+// when none has been configured
+func GetFeatureTagFilter() string {
+	if featureTagFilter != "" {
+		return featureTagFilter
+	}
+	return os.Getenv("VERIFY_CONFORMANCE_FEATURE_TAGS")
+}
+
+// SetSuccessComment configures a message appended to the result comment when
+// every requirement passes, e.g. congratulating the submitter and outlining
+// the review and merge steps that follow. An empty message appends nothing.
+func SetSuccessComment(comment string) {
+	successComment = strings.TrimSpace(comment)
+}
+
+// OrgOverrides carries per-org settings that take precedence over the
+// matching global configuration when PRs of that org are handled. Nil fields
+// fall back to the global value.
+type OrgOverrides struct {
+	// overrides the footer appended to every comment
+	CommentFooter *string `json:"commentFooter,omitempty"`
+	// overrides the message appended when every requirement passes
+	SuccessComment *string `json:"successComment,omitempty"`
+	// overrides the godog tag expression limiting suite runs
+	FeatureTagFilter *string `json:"featureTags,omitempty"`
+	// overrides how many minor versions behind the latest release still
+	// qualify for conformance submission
+	SupportedReleases *int `json:"supportedReleases,omitempty"`
+}
+
+// SetOrgOverrides configures per-org settings taking precedence over the
+// matching global configuration, keyed by org login. The effective settings
+// of each org are logged so a misconfigured override is visible at startup.
+func SetOrgOverrides(overrides map[string]OrgOverrides) error {
+	for org, o := range overrides {
+		if strings.TrimSpace(org) == "" {
+			return fmt.Errorf("org overrides must be keyed by a non-empty org login")
+		}
+		if o.SupportedReleases != nil && *o.SupportedReleases < 0 {
+			return fmt.Errorf("the supported releases override for org '%v' must not be negative", org)
+		}
+	}
+	orgOverrides = map[string]OrgOverrides{}
+	for org, o := range overrides {
+		orgOverrides[org] = o
+		logrus.Infof("org '%v' overrides: commentFooter '%v', successComment '%v', featureTags '%v', supportedReleases %v",
+			org, commentFooterForOrg(org), successCommentForOrg(org), featureTagFilterForOrg(org), supportedReleasesForOrg(org))
+	}
+	return nil
+}
+
+// SetOrgOverridesFromFile loads per-org overrides from a YAML or JSON file
+// mapping org logins to override fields. An empty path clears any overrides.
+func SetOrgOverridesFromFile(overridesPath string) error {
+	if overridesPath == "" {
+		return SetOrgOverrides(nil)
+	}
+	contents, err := os.ReadFile(overridesPath)
+	if err != nil {
+		return fmt.Errorf("unable to read the org overrides file '%v', %v", overridesPath, err)
+	}
+	overrides := map[string]OrgOverrides{}
+	if err := yaml.Unmarshal(contents, &overrides); err != nil {
+		return fmt.Errorf("unable to parse the org overrides file '%v', %v", overridesPath, err)
+	}
+	return SetOrgOverrides(overrides)
+}
+
+// commentFooterForOrg returns the comment footer configured for an org,
+// falling back to the global footer.
+func commentFooterForOrg(org string) string {
+	if o, ok := orgOverrides[org]; ok && o.CommentFooter != nil {
+		return strings.TrimSpace(*o.CommentFooter)
+	}
+	return commentFooter
+}
+
+// successCommentForOrg returns the success message configured for an org,
+// falling back to the global message.
+func successCommentForOrg(org string) string {
+	if o, ok := orgOverrides[org]; ok && o.SuccessComment != nil {
+		return strings.TrimSpace(*o.SuccessComment)
+	}
+	return successComment
+}
+
+// featureTagFilterForOrg returns the godog tag expression configured for an
+// org, falling back to the global filter.
+func featureTagFilterForOrg(org string) string {
+	if o, ok := orgOverrides[org]; ok && o.FeatureTagFilter != nil {
+		return strings.TrimSpace(*o.FeatureTagFilter)
+	}
+	return GetFeatureTagFilter()
+}
+
+// supportedReleasesForOrg returns the count of qualifying minor versions
+// configured for an org, or nil when the global count applies.
+func supportedReleasesForOrg(org string) *int {
+	if o, ok := orgOverrides[org]; ok && o.SupportedReleases != nil {
+		return o.SupportedReleases
+	}
+	return nil
+}
+
+// SetRequiredAcknowledgment configures a phrase the PR description must
+// contain before checks run, e.g. an agreement to the conformance terms. The
+// phrase is matched case-insensitively; an empty phrase disables the check.
+func SetRequiredAcknowledgment(phrase string) {
+	requiredAcknowledgment = strings.TrimSpace(phrase)
+}
+
+// SetExpectedBaseBranch configures the base branch submissions must target,
+// e.g. master. PRs opened against another branch receive an advisory comment
+// and the wrong-base-branch label; an empty branch disables the check.
+func SetExpectedBaseBranch(branch string) {
+	expectedBaseBranch = strings.TrimSpace(branch)
+}
+
+// SetRetainCommentHistory configures whether earlier bot comments are kept
+// when a new result is posted. By default stale comments are pruned so only
+// the latest result remains.
+func SetRetainCommentHistory(retain bool) {
+	retainCommentHistory = retain
+}
+
+// SetStaleSubmissionAge configures how long a submission may carry a failing
+// label before a reminder comment is posted.
+func SetStaleSubmissionAge(age time.Duration) {
+	if age <= 0 {
+		return
+	}
+	staleSubmissionAge = age
+}
+
+// SetConformancePRTitlePattern configures the regular expression used to
+// recognise conformance submission titles, overriding the default. The
+// optional named groups 'version' and 'product' are extracted into the match
+// result when present. An empty pattern leaves the current value.
+func SetConformancePRTitlePattern(pattern string) error {
+	if pattern == "" {
+		return nil
+	}
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("unable to compile title pattern '%v', %v", pattern, err)
+	}
+	conformancePRTitlePattern = compiled
+	return nil
+}
+
+// SetDryRun configures whether labels, comments, and statuses are only
+// logged rather than applied to the PR.
+func SetDryRun(enabled bool) {
+	dryRun = enabled
+}
+
+// SetChecksRunEnabled configures whether suite results are reported through a
+// GitHub Checks run with per-requirement annotations. When creating the run
+// fails, such as when the GitHub App lacks the checks permission, the commit
+// status path is used instead.
+func SetChecksRunEnabled(enabled bool) {
+	checksRunEnabled = enabled
+}
+
 type ProductYAMLField struct {
 	Field string
 }
 
+// githubClient is the surface of the GitHub API the plugin uses. Clients
+// whose credentials can lapse mid-scan, such as a GitHub App installation
+// token, may additionally implement reAuthenticator; handleAll then retries
+// a PR once through that hook when a call fails with a credential error.
 type githubClient interface {
 	CreateStatus(string, string, string, github.Status) error
 	GetCombinedStatus(org, repo, ref string) (*github.CombinedStatus, error)
@@ -85,13 +742,44 @@ type githubClient interface {
 	RemoveLabel(org, repo string, number int, label string) error
 	DeleteStaleComments(org, repo string, number int, comments []github.IssueComment, isStale func(github.IssueComment) bool) error
 	QueryWithGitHubAppsSupport(context.Context, interface{}, map[string]interface{}, string) error
+	MutateWithGitHubAppsSupport(context.Context, interface{}, githubql.Input, map[string]interface{}, string) error
 	GetPullRequest(org, repo string, number int) (*github.PullRequest, error)
 	GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error)
+	GetFile(org, repo, filepath, commit string) ([]byte, error)
+	IsMember(org, user string) (bool, error)
+}
+
+// reAuthenticator is optionally implemented by github clients that can
+// refresh their credentials in place, such as by minting a new GitHub App
+// installation token
+type reAuthenticator interface {
+	ReAuthenticate() error
+}
+
+// labelReplacer is optionally implemented by github clients that can replace
+// the full label set of an issue in one request, via GitHub's replace-all
+// labels endpoint; when available it saves an API call per label on PRs
+// needing several label changes at once
+type labelReplacer interface {
+	ReplaceLabels(org, repo string, number int, labels []string) error
+}
+
+// isCredentialError reports whether an error from the github client indicates
+// expired or rejected credentials rather than a transient failure
+func isCredentialError(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := err.Error()
+	return strings.Contains(message, "status code 401") ||
+		strings.Contains(message, "401 Unauthorized") ||
+		strings.Contains(message, "Bad credentials")
 }
 
 type PullRequest struct {
 	Number     githubql.Int
 	HeadRefOID githubql.String
+	IsDraft    githubql.Boolean
 	Author     struct {
 		Login githubql.String
 	}
@@ -110,7 +798,7 @@ type PullRequest struct {
 		Nodes []struct {
 			Path githubql.String
 		}
-	} `graphql:"files(first:10)"`
+	} `graphql:"files(first:100)"`
 	Title   githubql.String
 	Commits struct {
 		Nodes []struct {
@@ -138,10 +826,17 @@ type IssueComment struct {
 	UpdatedAt time.Time
 }
 
+type RepositoryQuery struct {
+	Repository struct {
+		ID githubql.ID
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
 type SearchQuery struct {
 	RateLimit struct {
 		Cost      githubql.Int
 		Remaining githubql.Int
+		ResetAt   githubql.DateTime
 	}
 	Search struct {
 		PageInfo struct {
@@ -163,30 +858,260 @@ func HelpProvider(_ []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
 		nil
 }
 
-func fetchFileFromURI(uri string) (content string, resp *http.Response, err error) {
-	resp, err = http.Get(uri)
+// outboundProxy returns the proxy for an outbound request: the explicitly
+// configured proxy when one is set, otherwise whatever HTTP_PROXY/HTTPS_PROXY
+// in the process environment name
+func outboundProxy(req *http.Request) (*url.URL, error) {
+	if outboundProxyURL != nil {
+		return http.ProxyURL(outboundProxyURL)(req)
+	}
+	return http.ProxyFromEnvironment(req)
+}
+
+// SetOutboundProxyURL configures an explicit proxy for outbound fetches,
+// overriding the proxy configured in the process environment. An empty URL
+// leaves the environment-based behaviour.
+func SetOutboundProxyURL(rawURL string) error {
+	if rawURL == "" {
+		return nil
+	}
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("unable to parse proxy url '%v', %v", rawURL, err)
+	}
+	outboundProxyURL = proxyURL
+	return nil
+}
+
+// SetGitHubGraphQLEndpoint configures the base URL of the GitHub GraphQL API,
+// e.g. 'https://github.example.com/api/graphql' for an enterprise deployment.
+// Repos configured with a prefix matching its host are scoped to the org that
+// follows the prefix. An empty endpoint assumes the public API.
+func SetGitHubGraphQLEndpoint(endpoint string) error {
+	if strings.TrimSpace(endpoint) == "" {
+		githubGraphQLEndpoint = nil
+		return nil
+	}
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("unable to parse GraphQL endpoint url '%v', %v", endpoint, err)
+	}
+	if endpointURL.Hostname() == "" {
+		return fmt.Errorf("GraphQL endpoint url '%v' does not name a host", endpoint)
+	}
+	githubGraphQLEndpoint = endpointURL
+	return nil
+}
+
+// orgRepoFromConfiguredRepo derives the org and the org/name pair used in
+// search queries from a configured repo, stripping an enterprise host prefix
+// (e.g. 'github.example.com/org/repo') when it matches the configured GraphQL
+// endpoint
+func orgRepoFromConfiguredRepo(repo string) (org string, orgRepo string, ok bool) {
+	slashSplit := strings.Split(repo, "/")
+	switch len(slashSplit) {
+	case 2:
+		return slashSplit[0], repo, true
+	case 3:
+		if githubGraphQLEndpoint == nil || !strings.EqualFold(slashSplit[0], githubGraphQLEndpoint.Hostname()) {
+			return "", "", false
+		}
+		return slashSplit[1], strings.Join(slashSplit[1:], "/"), true
+	}
+	return "", "", false
+}
+
+// HTTPDoer performs HTTP requests and is satisfied by *http.Client. It
+// exists so outbound fetches can be routed through a custom transport or
+// answered by a stub in tests.
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// SetHTTPClient configures the client performing all outbound fetches — PR
+// files, the product logo, and the PRODUCT.yaml URL checks — in place of the
+// defaults built from the configured timeout and TLS settings.
+func SetHTTPClient(client HTTPDoer) {
+	if client == nil {
+		return
+	}
+	fetchClient = client
+	productYAMLURLClient = client
+}
+
+func fetchFileFromURIOnce(ctx context.Context, client HTTPDoer, uri string) (content string, resp *http.Response, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	resp, err = client.Do(req)
 	if err != nil {
 		return "", nil, err
 	}
 	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
+	// read one byte past the cap so that callers can tell an oversized
+	// response apart from one that is exactly at the limit
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFileSizeBytes+1))
 	if err != nil {
 		return "", nil, err
 	}
 	return string(body), resp, nil
 }
 
+// fetches the file at uri, retrying with exponential backoff on 5xx responses
+// and network errors. Other response codes, such as 404, are returned as-is.
+func fetchFileFromURI(ctx context.Context, uri string) (content string, resp *http.Response, err error) {
+	return fetchFileFromURIWithClient(ctx, fetchClient, uri)
+}
+
+// as fetchFileFromURI, through an explicitly provided client
+func fetchFileFromURIWithClient(ctx context.Context, client HTTPDoer, uri string) (content string, resp *http.Response, err error) {
+	backoff := fetchFileRetryBackoff
+	for attempt := 1; ; attempt++ {
+		content, resp, err = fetchFileFromURIOnce(ctx, client, uri)
+		if err == nil && resp.StatusCode < http.StatusInternalServerError {
+			return content, resp, nil
+		}
+		if err == nil {
+			err = fmt.Errorf("received response code %v fetching '%v'", resp.StatusCode, uri)
+		}
+		if attempt >= fetchFileMaxAttempts {
+			return "", resp, err
+		}
+		select {
+		case <-ctx.Done():
+			return "", nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
 // takes a patchUrl from a githubClient.PullRequestChange and transforms it
 // to produce the url that delivers the raw file associated with the patch.
+// GitHub Enterprise hosts serve raw content from the same host under /raw,
+// whereas github.com serves it from raw.githubusercontent.com.
 // Tested for small files.
 func rawURLForBlobURL(patchUrl string) string {
-	fileUrl := strings.Replace(patchUrl, "github.com", "raw.githubusercontent.com", 1)
-	fileUrl = strings.Replace(fileUrl, "/blob", "", 1)
-	return fileUrl
+	u, err := url.Parse(patchUrl)
+	if err != nil {
+		return patchUrl
+	}
+	if u.Host == "github.com" {
+		u.Host = "raw.githubusercontent.com"
+		u.Path = strings.Replace(u.Path, "/blob", "", 1)
+		return u.String()
+	}
+	u.Path = strings.Replace(u.Path, "/blob/", "/raw/", 1)
+	return u.String()
+}
+
+var (
+	// GitHub truncates the raw diff view of very large files; content at or
+	// beyond this size is re-fetched in full through the git blobs API
+	largeFileSizeThreshold = 1024 * 1024
+	githubAPIBaseURL       = "https://api.github.com"
+	// fetched files larger than this are not held in memory in full; the
+	// submission is labelled instead so a human can take a look
+	maxFileSizeBytes = int64(250 * 1024 * 1024)
+)
+
+// SetMaxFileSizeBytes configures the largest file size fetched and held in
+// memory when checking a submission.
+func SetMaxFileSizeBytes(size int64) {
+	if size > 0 {
+		maxFileSizeBytes = size
+	}
+}
+
+// the shape of a git blobs API response; the content of a blob is served
+// base64-encoded regardless of size
+type blobContent struct {
+	Content  string `json:"content"`
+	Encoding string `json:"encoding"`
+	Size     int    `json:"size"`
+}
+
+// fetchBlobViaAPI retrieves the complete content of a blob through the git
+// blobs API, which serves files of any size, unlike the raw diff view
+func fetchBlobViaAPI(ctx context.Context, client HTTPDoer, org string, repo string, sha string) (string, error) {
+	uri := fmt.Sprintf("%v/repos/%v/%v/git/blobs/%v", githubAPIBaseURL, org, repo, sha)
+	body, _, err := fetchFileFromURIWithClient(ctx, client, uri)
+	if err != nil {
+		return "", err
+	}
+	blob := blobContent{}
+	if err := json.Unmarshal([]byte(body), &blob); err != nil {
+		return "", fmt.Errorf("unable to parse the blob response for '%v', %v", sha, err)
+	}
+	if blob.Encoding != "base64" {
+		return blob.Content, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(blob.Content, "\n", ""))
+	if err != nil {
+		return "", fmt.Errorf("unable to decode the blob content for '%v', %v", sha, err)
+	}
+	return string(decoded), nil
+}
+
+var gzipMagicBytes = []byte{0x1f, 0x8b}
+
+// detects gzip-compressed file content by the .gz extension on the file name
+// or by the gzip magic bytes, and transparently decompresses it.
+// Plaintext content is returned unchanged.
+func decompressContentIfGzip(fileName string, content string) (string, error) {
+	if !strings.HasSuffix(fileName, ".gz") && !bytes.HasPrefix([]byte(content), gzipMagicBytes) {
+		return content, nil
+	}
+	reader, err := gzip.NewReader(strings.NewReader(content))
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	return string(decompressed), nil
+}
+
+// searchErrorIsRetryable reports whether a search error looks like a
+// transient condition — a secondary rate limit, abuse detection, or a server
+// error — that is worth retrying with backoff
+func searchErrorIsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := strings.ToLower(err.Error())
+	for _, hint := range []string{"secondary rate limit", "abuse detection", "retry-after", "status code 403", "403 forbidden", "status code 50", "timeout", "connection reset"} {
+		if strings.Contains(message, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchErrorRetryAfter returns the wait requested by a Retry-After hint
+// embedded in the error, if one is present
+func searchErrorRetryAfter(err error) (time.Duration, bool) {
+	match := searchRetryAfterPattern.FindStringSubmatch(strings.ToLower(err.Error()))
+	if len(match) != 2 {
+		return 0, false
+	}
+	seconds, convErr := strconv.Atoi(match[1])
+	if convErr != nil {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
 }
 
-// Executes the search query contained in q using the GitHub client ghc
-func search(ctx context.Context, log *logrus.Entry, ghc githubClient, q string, org string) ([]suite.PullRequestQuery, error) {
+// Executes the search query contained in q using the GitHub client ghc.
+// Transient errors are retried with backoff, preserving the cursor so the
+// loop resumes at the page that failed. When too few rate limit points
+// remain, pagination pauses until the limit resets; if the context ends
+// first the sweep is truncated, returning the pages fetched so far along
+// with true
+func search(ctx context.Context, log *logrus.Entry, ghc githubClient, q string, org string) ([]suite.PullRequestQuery, bool, error) {
 	var ret []suite.PullRequestQuery
 	vars := map[string]interface{}{
 		"query":        githubql.String(q),
@@ -197,10 +1122,30 @@ func search(ctx context.Context, log *logrus.Entry, ghc githubClient, q string,
 	for {
 		sq := SearchQuery{}
 		log.Infof("query \"%s\" ", q)
-		if err := ghc.QueryWithGitHubAppsSupport(ctx, &sq, vars, org); err != nil {
-			return nil, err
+		backoff := searchRetryBackoff
+		for attempt := 1; ; attempt++ {
+			sq = SearchQuery{}
+			err := ghc.QueryWithGitHubAppsSupport(ctx, &sq, vars, org)
+			if err == nil {
+				break
+			}
+			if !searchErrorIsRetryable(err) || attempt >= searchMaxAttempts {
+				return nil, false, err
+			}
+			wait := backoff
+			if retryAfter, ok := searchErrorRetryAfter(err); ok {
+				wait = retryAfter
+			}
+			log.Warnf("search : attempt %d of %d failed with a transient error; retrying in %v, %v", attempt, searchMaxAttempts, wait, err)
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				log.Warnf("search : truncating sweep with %d result(s) fetched so far; %v", len(ret), ctx.Err())
+				return ret, true, nil
+			}
+			backoff *= 2
 		}
-		fmt.Printf("%+v\n", sq)
+		log.Debugf("search query response: %+v", sq)
 		totalCost += int(sq.RateLimit.Cost)
 		remaining = int(sq.RateLimit.Remaining)
 		for _, n := range sq.Search.Nodes {
@@ -209,70 +1154,233 @@ func search(ctx context.Context, log *logrus.Entry, ghc githubClient, q string,
 		if !sq.Search.PageInfo.HasNextPage {
 			break
 		}
+		if remaining < searchRateLimitThreshold {
+			if wait := time.Until(sq.RateLimit.ResetAt.Time); wait > 0 {
+				log.Warnf("search : %d rate limit point(s) remaining is below the threshold (%d); pausing %v until reset", remaining, searchRateLimitThreshold, wait.Round(time.Second))
+				select {
+				case <-time.After(wait):
+				case <-ctx.Done():
+					log.Warnf("search : truncating sweep with %d result(s) fetched so far; %v", len(ret), ctx.Err())
+					return ret, true, nil
+				}
+			}
+		}
 		vars["searchCursor"] = githubql.NewString(sq.Search.PageInfo.EndCursor)
 	}
 	log.Infof("Search for query \"%s\" cost %d point(s). %d remaining.", q, totalCost, remaining)
-	return ret, nil
+	return ret, false, nil
 }
 
-func NewPRSuiteForPR(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery) (prSuite *suite.PRSuite, err error) {
-	prSuite = suite.NewPRSuite(&suite.PullRequest{PullRequestQuery: *pr})
-	issueLabels, err := ghc.GetIssueLabels(string(pr.Repository.Owner.Login), string(pr.Repository.Name), int(pr.Number))
-	if err != nil {
-		return &suite.PRSuite{}, fmt.Errorf("error fetching PR issue labels for issue (%v), %v ", pr.Number, err)
-	}
-	for _, l := range issueLabels {
-		prSuite.PR.Labels = append(prSuite.PR.Labels, l.Name)
-	}
+// PRSuiteErrorCategory classifies a failure from NewPRSuiteForPR so that
+// callers can decide between retrying and messaging the submitter
+type PRSuiteErrorCategory string
 
-	stableTxt, err := common.GetStableTxt()
+const (
+	// PRSuiteErrorTransient covers GitHub API and network failures which are
+	// likely to succeed on a later attempt
+	PRSuiteErrorTransient PRSuiteErrorCategory = "transient"
+	// PRSuiteErrorContent covers problems with the submitted files themselves
+	// which only the submitter can correct
+	PRSuiteErrorContent PRSuiteErrorCategory = "content"
+)
+
+// PRSuiteError carries the category of a failure from NewPRSuiteForPR along
+// with the underlying error
+type PRSuiteError struct {
+	Category PRSuiteErrorCategory
+	Err      error
+}
+
+func (e *PRSuiteError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PRSuiteError) Unwrap() error {
+	return e.Err
+}
+
+func transientPRSuiteError(format string, a ...interface{}) error {
+	return &PRSuiteError{Category: PRSuiteErrorTransient, Err: fmt.Errorf(format, a...)}
+}
+
+func contentPRSuiteError(format string, a ...interface{}) error {
+	return &PRSuiteError{Category: PRSuiteErrorContent, Err: fmt.Errorf(format, a...)}
+}
+
+// prSuiteErrorCategory returns the category attached to an error from
+// NewPRSuiteForPR, or an empty category when the error carries none
+func prSuiteErrorCategory(err error) PRSuiteErrorCategory {
+	var prSuiteErr *PRSuiteError
+	if errors.As(err, &prSuiteErr) {
+		return prSuiteErr.Category
+	}
+	return PRSuiteErrorCategory("")
+}
+
+func NewPRSuiteForPR(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery) (prSuite *suite.PRSuite, err error) {
+	return newPRSuiteForPRWithClient(log, ghc, pr, fetchClient)
+}
+
+// as NewPRSuiteForPR, fetching file contents through an explicitly provided
+// client
+func newPRSuiteForPRWithClient(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery, client HTTPDoer) (prSuite *suite.PRSuite, err error) {
+	prSuite = suite.NewPRSuite(&suite.PullRequest{PullRequestQuery: *pr})
+	issueLabels, err := ghc.GetIssueLabels(string(pr.Repository.Owner.Login), string(pr.Repository.Name), int(pr.Number))
+	if err != nil {
+		return &suite.PRSuite{}, transientPRSuiteError("error fetching PR issue labels for issue (%v), %v ", pr.Number, err)
+	}
+	for _, l := range issueLabels {
+		prSuite.PR.Labels = append(prSuite.PR.Labels, l.Name)
+	}
+
+	stableTxt, err := common.GetStableTxt()
 	if err != nil {
-		return &suite.PRSuite{}, fmt.Errorf("unable to read latest version info")
+		return &suite.PRSuite{}, transientPRSuiteError("unable to read latest version info")
 	}
 	prSuite.KubernetesReleaseVersionLatest = stableTxt
 
 	var productYAMLContent string
+	var productYAMLFound bool
 	changes, err := ghc.GetPullRequestChanges(string(pr.Repository.Owner.Login), string(pr.Repository.Name), int(pr.Number))
 	if err != nil {
-		return &suite.PRSuite{}, fmt.Errorf("error fetching PR (%v) changes, %v", pr.Number, err)
+		return &suite.PRSuite{}, transientPRSuiteError("error fetching PR (%v) changes, %v", pr.Number, err)
+	}
+	if len(changes) == 0 {
+		log.Printf("PR (%v) changes no files", pr.Number)
+		prSuite.HasNoFilesChanged = true
+		return prSuite, nil
 	}
+	// a PR that doesn't add any files is a correction to an already-merged
+	// submission rather than a new one
+	isCorrection := true
 	for _, c := range changes {
-		content, _, err := fetchFileFromURI(rawURLForBlobURL(c.BlobURL))
+		if c.Status == github.PullRequestFileAdded {
+			isCorrection = false
+			break
+		}
+	}
+	prSuite.PR.IsCorrection = isCorrection
+	// private repositories don't serve raw content anonymously, so their
+	// files are fetched through the authenticated contents API instead
+	repoIsPrivate := false
+	if pullRequest, err := ghc.GetPullRequest(string(pr.Repository.Owner.Login), string(pr.Repository.Name), int(pr.Number)); err != nil {
+		log.Printf("unable to determine whether the repo of PR (%v) is private, %v; assuming it is public", pr.Number, err)
+	} else {
+		repoIsPrivate = pullRequest.Base.Repo.Private
+	}
+	for _, c := range changes {
+		var content string
+		if repoIsPrivate {
+			fileBytes, err := ghc.GetFile(string(pr.Repository.Owner.Login), string(pr.Repository.Name), c.Filename, string(pr.HeadRefOID))
+			if err != nil {
+				return &suite.PRSuite{}, transientPRSuiteError("error fetching content of '%v' in PR (%v) via the contents API, %v", c.Filename, pr.Number, err)
+			}
+			content = string(fileBytes)
+		} else {
+			content, _, err = fetchFileFromURIWithClient(context.TODO(), client, rawURLForBlobURL(c.BlobURL))
+			if err != nil {
+				return &suite.PRSuite{}, transientPRSuiteError("error fetching content of '%v' in PR (%v) via '%v', %v", c.Filename, pr.Number, c.BlobURL, err)
+			}
+			if len(content) >= largeFileSizeThreshold && c.SHA != "" {
+				fullContent, err := fetchBlobViaAPI(context.TODO(), client, string(pr.Repository.Owner.Login), string(pr.Repository.Name), c.SHA)
+				if err != nil {
+					log.Printf("unable to fetch the full blob of '%v' in PR (%v), %v; continuing with the raw content", c.Filename, pr.Number, err)
+				} else if len(fullContent) >= len(content) {
+					content = fullContent
+				}
+			}
+		}
+		content, err = decompressContentIfGzip(c.Filename, content)
 		if err != nil {
-			return &suite.PRSuite{}, fmt.Errorf("error fetching content of '%v' in PR (%v) via '%v', %v", c.Filename, pr.Number, c.BlobURL, err)
+			return &suite.PRSuite{}, contentPRSuiteError("error decompressing content of '%v' in PR (%v), %v", c.Filename, pr.Number, err)
 		}
 
-		baseName := path.Base(c.Filename)
+		baseName := strings.TrimSuffix(path.Base(c.Filename), ".gz")
+		fetchedSize := len(content)
+		if int64(len(content)) > maxFileSizeBytes {
+			log.Printf("the file '%v' in PR (%v) is larger than the maximum of %v bytes; its full contents will not be checked", c.Filename, pr.Number, maxFileSizeBytes)
+			prSuite.Labels = append(prSuite.Labels, fmt.Sprintf("oversized-file-%v", baseName))
+			if baseName == "e2e.log" {
+				// the head of the log is enough for version detection
+				content = content[:int(maxFileSizeBytes)]
+			} else {
+				content = ""
+			}
+		}
+		rawContents := ""
+		if baseName == "e2e.log" {
+			if cleaned, changed := suite.NormalizeLogContent(content); changed {
+				log.Printf("sanitized terminal escape sequences or invalid UTF-8 out of '%v' in PR (%v)", c.Filename, pr.Number)
+				rawContents = content
+				content = cleaned
+				prSuite.E2eLogWasSanitized = true
+			}
+		}
 		prFile := &suite.PullRequestFile{
-			Name:     c.Filename,
-			BaseName: baseName,
-			BlobURL:  c.BlobURL,
-			Contents: content,
+			Name:        c.Filename,
+			BaseName:    baseName,
+			BlobURL:     c.BlobURL,
+			SHA:         c.SHA,
+			Contents:    content,
+			RawContents: rawContents,
+			FetchedSize: fetchedSize,
+			Status:      c.Status,
 		}
 		prSuite.PR.SupportingFiles = append(prSuite.PR.SupportingFiles, prFile)
 
 		if baseName == "PRODUCT.yaml" {
+			productYAMLFound = true
 			productYAMLContent = content
 		}
 	}
-	if productYAMLContent == "" {
+	if !productYAMLFound {
 		log.Printf("failed to find PRODUCT.yaml from the list of files in the PR (%v)", pr.Number)
 		return prSuite, nil
 	}
 
-	productYAML := map[string]string{}
-	err = yaml.Unmarshal([]byte(productYAMLContent), &productYAML)
+	// a blank file or one unmarshalling into an empty map (such as a lone
+	// 'null' document) is reported separately from a missing file
+	if strings.TrimSpace(productYAMLContent) == "" {
+		log.Printf("PRODUCT.yaml in PR (%v) is empty", pr.Number)
+		prSuite.ProductYAMLIsEmpty = true
+		return prSuite, nil
+	}
+	productYAMLRaw := map[string]interface{}{}
+	err = yaml.Unmarshal([]byte(productYAMLContent), &productYAMLRaw)
 	if err != nil {
 		log.Printf("failed to parse content of PRODUCT.yaml in PR (%v), %v", pr.Number, err)
 		return prSuite, nil
 	}
+	if len(productYAMLRaw) == 0 {
+		log.Printf("PRODUCT.yaml in PR (%v) is empty", pr.Number)
+		prSuite.ProductYAMLIsEmpty = true
+		return prSuite, nil
+	}
+	// scalar values such as unquoted numbers or booleans are coerced to
+	// strings rather than abandoning the whole file
+	productYAML := map[string]string{}
+	for field, value := range productYAMLRaw {
+		switch typedValue := value.(type) {
+		case nil:
+		case string:
+			productYAML[field] = typedValue
+		default:
+			productYAML[field] = fmt.Sprintf("%v", typedValue)
+			log.Printf("coerced field '%v' in PRODUCT.yaml in PR (%v) from %T to string", field, pr.Number, typedValue)
+		}
+	}
 
+	resolvedURLFieldCount := 0
 	for _, f := range productYAMLRequiredFieldDateTypes {
 		uri := productYAML[f.Field]
 		if uri == "" {
 			log.Printf("field '%v' is empty in PRODUCT.yaml, not resolving URL\n", f.Field)
 			continue
 		}
+		if handleAllStagger > 0 && resolvedURLFieldCount > 0 {
+			sleep(handleAllStagger)
+		}
+		resolvedURLFieldCount++
 		if prSuite.PR.ProductYAMLURLDataTypes == nil {
 			prSuite.PR.ProductYAMLURLDataTypes = map[string]string{}
 		}
@@ -285,35 +1393,113 @@ func NewPRSuiteForPR(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQ
 		if u.Scheme == "" {
 			u.Scheme = "https"
 		}
+		if reason := productYAMLURLBlockedReason(u.Hostname()); reason != "" {
+			log.Printf("refusing to resolve url '%v' from the field '%v' in PRODUCT.yaml in PR (%v); %v", u, f.Field, pr.Number, reason)
+			continue
+		}
 		req, err := http.NewRequest(http.MethodHead, u.String(), nil)
 		if err != nil {
 			log.Printf("failed to prepare new request for URL (%v) for PR (%v), %v", u, pr.Number, err)
 			continue
 		}
-		tr := &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		var client HTTPDoer = productYAMLURLClient
+		if client == nil {
+			tr := &http.Transport{
+				Proxy:           outboundProxy,
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: productYAMLURLChecksInsecureSkipTLSVerify, RootCAs: productYAMLURLRootCAs},
+			}
+			client = &http.Client{Transport: tr, Timeout: productYAMLURLCheckTimeout, CheckRedirect: checkURLRedirect}
 		}
-		client := &http.Client{Transport: tr}
 		resp, err := client.Do(req)
 		if err != nil {
 			log.Printf("failed to make a HEAD request to url '%v' from the field '%v' in PRODUCT.yaml in PR (%v), %v", u, f.Field, pr.Number, err)
+			// a bounded or looping redirect chain is the submitter's problem
+			// rather than a transient network failure; record it so the
+			// suite can report it against the field
+			if errors.Is(err, errRedirectLoop) || errors.Is(err, errTooManyRedirects) {
+				if prSuite.PR.ProductYAMLURLFailureReasons == nil {
+					prSuite.PR.ProductYAMLURLFailureReasons = map[string]string{}
+				}
+				reason := errTooManyRedirects.Error()
+				if errors.Is(err, errRedirectLoop) {
+					reason = errRedirectLoop.Error()
+				}
+				prSuite.PR.ProductYAMLURLFailureReasons[f.Field] = reason
+			}
 			continue
 		}
+		// some hosts reject HEAD requests but answer GET fine; fall back to a
+		// ranged GET so the content-type can still be resolved
+		if resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusForbidden {
+			getReq, err := http.NewRequest(http.MethodGet, u.String(), nil)
+			if err != nil {
+				log.Printf("failed to prepare new GET request for URL (%v) for PR (%v), %v", u, pr.Number, err)
+				continue
+			}
+			getReq.Header.Set("Range", "bytes=0-0")
+			getResp, err := client.Do(getReq)
+			if err != nil {
+				log.Printf("failed to make a GET request to url '%v' from the field '%v' in PRODUCT.yaml in PR (%v), %v", u, f.Field, pr.Number, err)
+				continue
+			}
+			getResp.Body.Close()
+			resp = getResp
+		}
 		contentType := resp.Header.Get("Content-Type")
-		log.Printf("%v: '%v' -> %v = '%v'\n", pr.Number, f.Field, u.String(), contentType)
+		log.Printf("%v: '%v' -> %v = '%v' (%v)\n", pr.Number, f.Field, u.String(), contentType, resp.StatusCode)
 		prSuite.PR.ProductYAMLURLDataTypes[f.Field] = contentType
+		if prSuite.PR.ProductYAMLURLStatusCodes == nil {
+			prSuite.PR.ProductYAMLURLStatusCodes = map[string]int{}
+		}
+		prSuite.PR.ProductYAMLURLStatusCodes[f.Field] = resp.StatusCode
+		// redirecting off the submitted domain is worth a look from a
+		// reviewer without blocking the submission
+		if finalURL := resp.Request.URL; finalURL != nil && !strings.EqualFold(finalURL.Hostname(), u.Hostname()) {
+			log.Printf("url '%v' from the field '%v' in PRODUCT.yaml in PR (%v) redirects to the different domain '%v'", u, f.Field, pr.Number, finalURL.Hostname())
+			if prSuite.PR.ProductYAMLURLRedirectHosts == nil {
+				prSuite.PR.ProductYAMLURLRedirectHosts = map[string]string{}
+			}
+			prSuite.PR.ProductYAMLURLRedirectHosts[f.Field] = finalURL.Hostname()
+		}
+	}
+
+	// the product logo content is fetched so the suite can confirm it is an SVG
+	if uri := productYAML["product_logo_url"]; uri != "" {
+		if logoURL, err := url.ParseRequestURI(uri); err == nil {
+			if reason := productYAMLURLBlockedReason(logoURL.Hostname()); reason != "" {
+				log.Printf("refusing to fetch the product logo from the url '%v' in PRODUCT.yaml in PR (%v); %v", uri, pr.Number, reason)
+				return prSuite, nil
+			}
+		}
+		content, resp, err := fetchFileFromURI(context.TODO(), uri)
+		if err != nil {
+			log.Printf("failed to fetch the product logo from the url '%v' in PRODUCT.yaml in PR (%v), %v", uri, pr.Number, err)
+		} else if resp.StatusCode == http.StatusOK {
+			prSuite.PR.ProductLogoContent = content
+		}
 	}
 
 	return prSuite, nil
 }
 
+// GetGodogPaths returns the candidate feature file directories that exist.
+// Directories listed in the VERIFY_CONFORMANCE_FEATURE_PATHS environment
+// variable (colon-separated) are considered before the built-in locations
 func GetGodogPaths() (paths []string) {
-	for _, p := range godogPaths {
+	candidatePaths := godogPaths
+	if extraPaths := os.Getenv("VERIFY_CONFORMANCE_FEATURE_PATHS"); extraPaths != "" {
+		candidatePaths = append(strings.Split(extraPaths, ":"), candidatePaths...)
+	}
+	for _, p := range candidatePaths {
+		if p == "" {
+			continue
+		}
 		if _, err := os.Stat(p); os.IsNotExist(err) {
 			continue
 		}
 		paths = append(paths, p)
 	}
+	logrus.Debugf("using feature paths: %v", paths)
 	return paths
 }
 
@@ -326,12 +1512,18 @@ func labelIsManaged(input string) bool {
 	return false
 }
 
+// a version label must end in an actual release version, so unrelated labels
+// sharing a template prefix, such as release-note, are never treated as
+// managed
+var versionLabelValuePattern = `v[0-9]+\.[0-9]+(\.[0-9]+)?(-[a-zA-Z0-9.]+)?`
+
 func labelIsVersionLabel(label, version string) bool {
 	for _, ml := range managedPRLabelTemplatesWithVersion {
-		if strings.Contains(label, strings.ReplaceAll(ml, "%v", "")) {
+		pattern, err := regexp.Compile("^" + strings.ReplaceAll(regexp.QuoteMeta(ml), "%v", versionLabelValuePattern) + "$")
+		if err == nil && pattern.MatchString(label) {
 			return true
 		}
-		if fmt.Sprintf(ml, version) == label {
+		if version != "" && fmt.Sprintf(ml, version) == label {
 			return true
 		}
 	}
@@ -352,9 +1544,22 @@ func labelIsFileLabel(label string, missingFiles []string) bool {
 	return false
 }
 
+// updateLabels reconciles the labels of a PR with the ones computed for it,
+// returning the deduplicated sets of labels that were added and removed.
+// Labels not managed by the plugin are left untouched. When more than one
+// change is needed and the client can replace the full label set in one
+// request, the changes are applied as a single replacement rather than a
+// call per label.
 func updateLabels(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery, prSuite *suite.PRSuite, labels []string) (newLabels, removedLabels []string, err error) {
+	// the computed set and the PR's labels can both carry duplicates;
+	// each label is reconciled, and reported, only once
+	seenLabels := map[string]bool{}
 labels:
 	for _, l := range labels {
+		if seenLabels[l] {
+			continue labels
+		}
+		seenLabels[l] = true
 		isManagedLabel := labelIsManaged(l)
 		isInVersionLabel := labelIsVersionLabel(l, prSuite.KubernetesReleaseVersion)
 		isInMissingFileLabel := labelIsFileLabel(l, prSuite.MissingFiles)
@@ -371,15 +1576,16 @@ labels:
 		if foundInLabels {
 			continue labels
 		}
-		if err := githubClient.AddLabel(ghc, string(pr.Repository.Owner.Login), string(pr.Repository.Name), int(pr.Number), l); err != nil {
-			return []string{}, []string{}, fmt.Errorf("failed to add label '%v' to %v/%v!%v", l, pr.Repository.Owner.Login, pr.Repository.Name, pr.Number)
-		}
 		newLabels = append(newLabels, l)
 	}
-	prSuite.PR.Labels = append(prSuite.PR.Labels, newLabels...)
 
+	seenPRLabels := map[string]bool{}
 prLabels:
 	for _, prl := range prSuite.PR.Labels {
+		if seenPRLabels[prl] {
+			continue prLabels
+		}
+		seenPRLabels[prl] = true
 		isManagedLabel := labelIsManaged(prl)
 		isInVersionLabel := labelIsVersionLabel(prl, prSuite.KubernetesReleaseVersion)
 		isInMissingFileLabel := labelIsFileLabel(prl, prSuite.MissingFiles)
@@ -397,17 +1603,65 @@ prLabels:
 		if foundInLabels {
 			continue prLabels
 		}
-		// log.Printf("Will remove label '%v'", prl)
-		if err := githubClient.RemoveLabel(ghc, string(pr.Repository.Owner.Login), string(pr.Repository.Name), int(pr.Number), prl); err != nil {
-			return []string{}, []string{}, fmt.Errorf("failed to add remove '%v' to %v/%v!%v", prl, pr.Repository.Owner.Login, pr.Repository.Name, pr.Number)
+		removedLabels = append(removedLabels, prl)
+	}
+
+	switch {
+	case dryRun:
+		for _, l := range newLabels {
+			log.Infof("dry-run: would add label '%v' to %v/%v!%v", l, pr.Repository.Owner.Login, pr.Repository.Name, pr.Number)
+		}
+		for _, prl := range removedLabels {
+			log.Infof("dry-run: would remove label '%v' from %v/%v!%v", prl, pr.Repository.Owner.Login, pr.Repository.Name, pr.Number)
+		}
+	case len(newLabels)+len(removedLabels) > 1:
+		// several changes are cheaper as one replacement of the full label
+		// set, when the client supports it; unmanaged labels are carried
+		// over into the replacement untouched
+		if lr, ok := ghc.(labelReplacer); ok {
+			replacement := []string{}
+			seenReplacement := map[string]bool{}
+			for _, l := range append(removeSliceOfStringsFromStringSlice(prSuite.PR.Labels, removedLabels), newLabels...) {
+				if seenReplacement[l] {
+					continue
+				}
+				seenReplacement[l] = true
+				replacement = append(replacement, l)
+			}
+			if err := lr.ReplaceLabels(string(pr.Repository.Owner.Login), string(pr.Repository.Name), int(pr.Number), replacement); err != nil {
+				return []string{}, []string{}, fmt.Errorf("failed to replace the labels on %v/%v!%v, %v", pr.Repository.Owner.Login, pr.Repository.Name, pr.Number, err)
+			}
+			break
+		}
+		fallthrough
+	default:
+		for _, l := range newLabels {
+			if err := githubClient.AddLabel(ghc, string(pr.Repository.Owner.Login), string(pr.Repository.Name), int(pr.Number), l); err != nil {
+				return []string{}, []string{}, fmt.Errorf("failed to add label '%v' to %v/%v!%v", l, pr.Repository.Owner.Login, pr.Repository.Name, pr.Number)
+			}
+		}
+		for _, prl := range removedLabels {
+			if err := githubClient.RemoveLabel(ghc, string(pr.Repository.Owner.Login), string(pr.Repository.Name), int(pr.Number), prl); err != nil {
+				return []string{}, []string{}, fmt.Errorf("failed to add remove '%v' to %v/%v!%v", prl, pr.Repository.Owner.Login, pr.Repository.Name, pr.Number)
+			}
 		}
-		removedLabels = append(prSuite.PR.Labels, prl)
 	}
+	prSuite.PR.Labels = append(prSuite.PR.Labels, newLabels...)
+	metrics.LabelsAddedTotal.Add(float64(len(newLabels)))
 	prSuite.PR.Labels = removeSliceOfStringsFromStringSlice(prSuite.PR.Labels, removedLabels)
 
 	return newLabels, removedLabels, nil
 }
 
+// commentDedupMarker returns a hidden HTML marker embedding a hash of the
+// comment with its whitespace normalized, so a comment posted by a prior run
+// is recognized across restarts and between replicas even when only its
+// formatting differs
+func commentDedupMarker(comment string) string {
+	sum := sha256.Sum256([]byte(strings.Join(strings.Fields(comment), " ")))
+	return fmt.Sprintf("<!-- %v-comment-hash: %x -->", PluginName, sum[:8])
+}
+
 func updateComments(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery, prSuite *suite.PRSuite, comment string) error {
 	comments, err := githubClient.ListIssueCommentsWithContext(ghc, context.TODO(), string(pr.Repository.Owner.Login), string(pr.Repository.Name), int(pr.Number))
 	if err != nil {
@@ -427,30 +1681,55 @@ func updateComments(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQu
 		}
 		botComments = append(botComments, c)
 	}
+	// the footer is part of the posted comment, so the dedup marker is
+	// computed over it too; changing the footer re-posts the comment
+	if footer := commentFooterForOrg(string(pr.Repository.Owner.Login)); footer != "" {
+		comment = fmt.Sprintf("%v\n\n---\n%v", strings.TrimRight(comment, "\n"), footer)
+	}
+	marker := commentDedupMarker(comment)
+	dedupComments := botComments
+	if retainCommentHistory && len(botComments) > 0 {
+		// with history retained, only the latest comment suppresses a
+		// repost, so an earlier state that recurs is still recorded
+		dedupComments = botComments[len(botComments)-1:]
+	}
+	for _, c := range dedupComments {
+		if strings.Contains(c.Body, marker) {
+			log.Printf("warning: nothing new to add in PR (%v)\n", int(pr.Number))
+			return nil
+		}
+	}
+	// comments posted before markers were embedded can only be matched exactly
 	if len(botComments) > 0 && botComments[len(botComments)-1].Body == comment {
 		log.Printf("warning: nothing new to add in PR (%v)\n", int(pr.Number))
 		return nil
 	}
-	botCommentsToPrune := botComments
-	if len(botComments) > 0 {
+	botCommentsToPrune := []github.IssueComment{}
+	if !retainCommentHistory && len(botComments) > 0 {
 		botCommentsToPrune = botComments[:len(botComments)-1]
 	}
+	if dryRun {
+		log.Infof("dry-run: would prune %v stale comment(s) and comment on PR (%v): %v", len(botCommentsToPrune), int(pr.Number), comment)
+		return nil
+	}
 
-	err = githubClient.DeleteStaleComments(
-		ghc,
-		string(pr.Repository.Owner.Login),
-		string(pr.Repository.Name),
-		int(pr.Number),
-		botCommentsToPrune,
-		func(ic github.IssueComment) bool {
-			return botUserChecker(ic.User.Login)
-		},
-	)
-	if err != nil {
-		return fmt.Errorf("unable to prune stale comments comments on PR (%v), %v", int(pr.Number), err)
+	if !retainCommentHistory {
+		err = githubClient.DeleteStaleComments(
+			ghc,
+			string(pr.Repository.Owner.Login),
+			string(pr.Repository.Name),
+			int(pr.Number),
+			botCommentsToPrune,
+			func(ic github.IssueComment) bool {
+				return botUserChecker(ic.User.Login)
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("unable to prune stale comments comments on PR (%v), %v", int(pr.Number), err)
+		}
 	}
 
-	err = githubClient.CreateComment(ghc, string(pr.Repository.Owner.Login), string(pr.Repository.Name), int(pr.Number), comment)
+	err = githubClient.CreateComment(ghc, string(pr.Repository.Owner.Login), string(pr.Repository.Name), int(pr.Number), fmt.Sprintf("%v\n%v", comment, marker))
 	if err != nil {
 		return err
 	}
@@ -470,83 +1749,519 @@ o:
 	return output
 }
 
+// ConformancePRTitleMatch records whether a PR title reads as a conformance
+// submission, along with the release version and product name extracted from
+// it when the title declares them.
+type ConformancePRTitleMatch struct {
+	IsConformance bool
+	Version       string
+	Product       string
+}
+
+// matchConformancePRTitle matches a PR title against the configured
+// conformance title pattern.
+func matchConformancePRTitle(title string) ConformancePRTitleMatch {
+	match := conformancePRTitlePattern.FindStringSubmatch(title)
+	if match == nil {
+		return ConformancePRTitleMatch{}
+	}
+	result := ConformancePRTitleMatch{IsConformance: true}
+	for i, name := range conformancePRTitlePattern.SubexpNames() {
+		switch name {
+		case "version":
+			result.Version = match[i]
+		case "product":
+			result.Product = strings.TrimSpace(match[i])
+		}
+	}
+	return result
+}
+
 func isConformancePR(pr *suite.PullRequestQuery) bool {
-	return strings.Contains(strings.ToLower(string(pr.Title)), "conformance results for")
+	return matchConformancePRTitle(string(pr.Title)).IsConformance
+}
+
+// ParseVersionFromTitle extracts the Kubernetes release version declared in a
+// PR title, normalised to the vMAJOR.MINOR form used for labels. The 'v'
+// prefix is optional and a patch suffix is dropped; the version may appear
+// anywhere in the title. Titles declaring multiple distinct versions are
+// rejected as ambiguous.
+func ParseVersionFromTitle(title string) (string, error) {
+	matches := titleVersionExtractPattern.FindAllStringSubmatch(title, -1)
+	if len(matches) == 0 {
+		return "", fmt.Errorf("unable to find a release version in title '%v'", title)
+	}
+	version := ""
+	for _, match := range matches {
+		found := fmt.Sprintf("v%v.%v", match[1], match[2])
+		if version != "" && found != version {
+			return "", fmt.Errorf("title '%v' declares multiple distinct release versions ('%v' and '%v')", title, version, found)
+		}
+		version = found
+	}
+	return version, nil
 }
 
 func updateStatus(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery, prSuite *suite.PRSuite, state string) error {
 	var description string
 	currentLatestHasCurrentStatus := false
-commitLoop:
-	for _, commit := range pr.Commits.Nodes {
-		if string(commit.Commit.Oid) != string(pr.HeadRefOID) {
-			continue
+	// the statuses embedded in the query's commit list bind to the SHAs the
+	// query saw: after a force-push they attach to an orphaned commit, and the
+	// query caps the list so a deep head is absent entirely. The head's status
+	// is therefore always fetched directly by its OID rather than trusted from
+	// the list, and a stale status on an old head is simply overwritten by
+	// setting the current one on the true head below.
+	if string(pr.HeadRefOID) != "" {
+		if cs, err := ghc.GetCombinedStatus(string(pr.Repository.Owner.Login), string(pr.Repository.Name), string(pr.HeadRefOID)); err == nil {
+			for _, status := range cs.Statuses {
+				if strings.EqualFold(status.Context, "verify-conformance") {
+					currentLatestHasCurrentStatus = strings.EqualFold(status.State, github.StatusSuccess)
+					break
+				}
+			}
+		} else {
+			log.Infof("PR %v failed to get combined status for head '%v': %v", pr.Number, pr.HeadRefOID, err)
+		}
+	} else if len(pr.Commits.Nodes) > 0 {
+		// without a head OID the commit list is all there is to go on; the
+		// newest listed commit stands in for the head
+		newest := pr.Commits.Nodes[len(pr.Commits.Nodes)-1]
+		for _, context := range newest.Commit.Status.Contexts {
+			if strings.EqualFold(string(context.Context), "verify-conformance") {
+				currentLatestHasCurrentStatus = strings.EqualFold(string(context.State), string(githubql.StatusStateSuccess))
+				break
+			}
+		}
+	}
+	if currentLatestHasCurrentStatus {
+		log.Infof("PR %v has status up to date", pr.Number)
+		return nil
+	}
+	switch state {
+	case "success":
+		description = "All checks are passing"
+	case "failure":
+		description = "Please check failing requirements and update accordingly"
+		if total, failed, err := prSuite.GetRequirementCountsFromSuiteResultsBuffer(); err == nil && failed > 0 {
+			description = fmt.Sprintf("%v of %v requirements are failing; see the PR comment for the breakdown", failed, total)
+		}
+	case "pending":
+		// pending covers both a suite still running and a submission waiting
+		// on corrections from the submitter
+		description = "Verification in progress / awaiting corrections"
+	case "error":
+		// the submission couldn't be evaluated, e.g. its recorded metadata
+		// failed to parse
+		description = "The submission could not be evaluated; see the PR comment for details"
+	default:
+		description = "Internal error"
+		log.Infof("PR %v has invalid state", pr.Number)
+	}
+	// GitHub truncates status descriptions at 140 characters
+	if len(description) > 140 {
+		description = description[:137] + "..."
+	}
+	targetURL := fmt.Sprintf("https://github.com/%v/%v/pull/%v", pr.Repository.Owner.Login, pr.Repository.Name, int(pr.Number))
+	log.Infof("PR %v setting state of '%v' with description '%v'", pr.Number, state, description)
+	cs, err := ghc.GetCombinedStatus(string(pr.Repository.Owner.Login), string(pr.Repository.Name), string(pr.HeadRefOID))
+	if err != nil {
+		log.Infof("PR %v failed to get combined status: %v", pr.Number, err)
+		return err
+	}
+	if cs.SHA == string(pr.HeadRefOID) && cs.State == state {
+		log.Infof("PR %v state unchanged", pr.Number)
+		return nil
+	}
+	if dryRun {
+		log.Infof("dry-run: would set a status of '%v' with description '%v' on PR %v", state, description, pr.Number)
+		return nil
+	}
+	if err := ghc.CreateStatus(
+		string(pr.Repository.Owner.Login), string(pr.Repository.Name), string(pr.HeadRefOID),
+		github.Status{
+			Context:     "verify-conformance",
+			State:       state,
+			Description: description,
+			TargetURL:   targetURL,
+		}); err != nil {
+		log.Infof("PR %v failed to create status: %v", pr.Number, err)
+		return err
+	}
+	return nil
+}
+
+// annotationForFailure points a failed requirement at the file in the
+// submission that most likely needs attention, preferring a file mentioned in
+// the failure and defaulting to PRODUCT.yaml
+func annotationForFailure(prSuite *suite.PRSuite, failure suite.ResultPrepare) githubql.CheckAnnotationData {
+	filePath := ""
+	failureText := failure.Name + " " + strings.Join(failure.Hints, " ")
+	for _, file := range prSuite.PR.SupportingFiles {
+		if strings.Contains(failureText, file.BaseName) {
+			filePath = file.Name
+			break
+		}
+	}
+	if filePath == "" {
+		if file := prSuite.GetFileByFileName("PRODUCT.yaml"); file != nil {
+			filePath = file.Name
+		}
+	}
+	message := failure.Name
+	if len(failure.Hints) > 0 {
+		message += ": " + strings.Join(failure.Hints, "; ")
+	}
+	return githubql.CheckAnnotationData{
+		Path: githubql.String(filePath),
+		Location: githubql.CheckAnnotationRange{
+			StartLine: githubql.Int(1),
+			EndLine:   githubql.Int(1),
+		},
+		AnnotationLevel: githubql.CheckAnnotationLevelFailure,
+		Message:         githubql.String(message),
+	}
+}
+
+// createCheckRun reports the suite results for the head commit of the PR as a
+// GitHub Checks run, annotating each failed requirement
+func createCheckRun(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery, prSuite *suite.PRSuite, state string, summary string) error {
+	repoQuery := RepositoryQuery{}
+	vars := map[string]interface{}{
+		"owner": githubql.String(pr.Repository.Owner.Login),
+		"name":  githubql.String(pr.Repository.Name),
+	}
+	if err := ghc.QueryWithGitHubAppsSupport(context.TODO(), &repoQuery, vars, string(pr.Repository.Owner.Login)); err != nil {
+		return fmt.Errorf("unable to resolve the repository id for the checks run, %v", err)
+	}
+	failures, err := prSuite.GetFailuresFromSuiteResultsBuffer()
+	if err != nil {
+		return err
+	}
+	annotations := []githubql.CheckAnnotationData{}
+	for _, failure := range failures {
+		annotations = append(annotations, annotationForFailure(prSuite, failure))
+	}
+	conclusion := githubql.CheckConclusionStateSuccess
+	switch state {
+	case "failure":
+		conclusion = githubql.CheckConclusionStateFailure
+	case "pending":
+		conclusion = githubql.CheckConclusionStateNeutral
+	}
+	if dryRun {
+		log.Infof("dry-run: would create a checks run with a conclusion of '%v' and %v annotation(s) on PR %v", conclusion, len(annotations), pr.Number)
+		return nil
+	}
+	log.Infof("PR %v creating checks run with conclusion of '%v' and %v annotation(s)", pr.Number, conclusion, len(annotations))
+	var mutation struct {
+		CreateCheckRun struct {
+			CheckRun struct {
+				ID githubql.ID
+			}
+		} `graphql:"createCheckRun(input: $input)"`
+	}
+	input := githubql.CreateCheckRunInput{
+		RepositoryID: repoQuery.Repository.ID,
+		Name:         githubql.String("verify-conformance"),
+		HeadSha:      githubql.GitObjectID(pr.HeadRefOID),
+		Status:       common.Pointer(githubql.RequestableCheckStatusStateCompleted),
+		Conclusion:   common.Pointer(conclusion),
+		Output: &githubql.CheckRunOutput{
+			Title:       githubql.String("verify-conformance"),
+			Summary:     githubql.String(summary),
+			Annotations: &annotations,
+		},
+	}
+	if err := ghc.MutateWithGitHubAppsSupport(context.TODO(), &mutation, input, nil, string(pr.Repository.Owner.Login)); err != nil {
+		return fmt.Errorf("unable to create the checks run, %v", err)
+	}
+	return nil
+}
+
+// Handler checks conformance submission PRs with explicitly injected
+// dependencies, so the logic can be constructed and driven from external
+// tooling and tests without the package-level wiring used by the event
+// handlers. Zero-valued fields fall back to the package defaults.
+type Handler struct {
+	GitHubClient   githubClient
+	Log            *logrus.Entry
+	FeaturePaths   []string
+	MetadataFolder string
+	HTTPClient     HTTPDoer
+}
+
+// NewHandler returns a Handler for the given GitHub client, with the
+// remaining dependencies at their package defaults.
+func NewHandler(ghc githubClient) *Handler {
+	return &Handler{GitHubClient: ghc}
+}
+
+func (h *Handler) log() *logrus.Entry {
+	if h.Log != nil {
+		return h.Log
+	}
+	return logrus.NewEntry(logrus.StandardLogger())
+}
+
+func (h *Handler) featurePaths() []string {
+	if len(h.FeaturePaths) > 0 {
+		return h.FeaturePaths
+	}
+	return GetGodogPaths()
+}
+
+func (h *Handler) metadataFolder() string {
+	if h.MetadataFolder != "" {
+		return h.MetadataFolder
+	}
+	return path.Join(common.GetDataPath(), "conformance-testdata")
+}
+
+func (h *Handler) httpClient() HTTPDoer {
+	if h.HTTPClient != nil {
+		return h.HTTPClient
+	}
+	return fetchClient
+}
+
+// handle checks a Conformance Certification PR to determine if the contents of the PR pass sanity checks.
+// Adds a comment to indicate whether or not the version in the PR title occurs in the supplied logs.
+func handle(ctx context.Context, log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery) error {
+	return (&Handler{GitHubClient: ghc, Log: log}).Handle(ctx, pr)
+}
+
+// Handle checks a Conformance Certification PR as handle does, using the
+// dependencies held by the Handler.
+func (h *Handler) Handle(ctx context.Context, pr *suite.PullRequestQuery) (err error) {
+	log := h.log()
+	ghc := h.GitHubClient
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if bool(pr.IsDraft) {
+		log.Infof("skipping draft PR (%v); it will be verified when marked ready for review", int(pr.Number))
+		return nil
+	}
+	start := time.Now()
+	defer func() {
+		metrics.HandleDuration.Observe(time.Since(start).Seconds())
+		result := "success"
+		if err != nil {
+			result = "error"
+		}
+		metrics.PRsHandledTotal.WithLabelValues(result).Inc()
+	}()
+	// a head already verified successfully doesn't need its artifacts
+	// re-fetched or the suite re-run; the recorded labels, comment, and
+	// status are reconciled instead. A new commit changes HeadRefOID and so
+	// misses the cache, and a recheck command invalidates it explicitly
+	if string(pr.HeadRefOID) != "" {
+		if state, ok := computedStateForPullRequest(pr); ok && state == "success" {
+			labels, labelsFound := computedLabelsForPullRequest(pr)
+			comment, commentFound := computedCommentForPullRequest(pr)
+			if labelsFound && commentFound {
+				log.Printf("PR (%v) head '%v' is already verified; reconciling without re-running the checks\n", int(pr.Number), string(pr.HeadRefOID))
+				prSuite := suite.NewPRSuite(&suite.PullRequest{PullRequestQuery: *pr})
+				issueLabels, err := ghc.GetIssueLabels(string(pr.Repository.Owner.Login), string(pr.Repository.Name), int(pr.Number))
+				if err != nil {
+					return fmt.Errorf("error fetching PR issue labels for issue (%v), %v ", pr.Number, err)
+				}
+				for _, l := range issueLabels {
+					prSuite.PR.Labels = append(prSuite.PR.Labels, l.Name)
+				}
+				if _, _, err := updateLabels(log, ghc, pr, prSuite, labels); err != nil {
+					return err
+				}
+				if err := updateComments(log, ghc, pr, prSuite, comment); err != nil {
+					return err
+				}
+				if err := updateStatus(log, ghc, pr, prSuite, state); err != nil {
+					return err
+				}
+				return nil
+			}
+		}
+	}
+	godogFeaturePaths := h.featurePaths()
+	prSuite, err := newPRSuiteForPRWithClient(log, ghc, pr, h.httpClient())
+	if err != nil {
+		// a problem with the submitted files themselves won't resolve on
+		// retry, so the submitter is told what to correct; network and API
+		// failures are left pending and returned so the next sync retries
+		if prSuiteErrorCategory(err) == PRSuiteErrorContent {
+			log.Printf("PR (%v) has a content problem, %v\n", int(pr.Number), err)
+			finalComment := strings.Join(
+				[]string{
+					"There was a problem processing a file in this submission; Checks will not run until it is corrected:",
+					"",
+					fmt.Sprintf("> %v", err),
+				},
+				"\n")
+			labels := []string{"conformance-product-submission", "unable-to-process"}
+			state := "pending"
+			emptySuite := suite.NewPRSuite(&suite.PullRequest{PullRequestQuery: *pr})
+			if _, _, err := updateLabels(log, ghc, pr, emptySuite, labels); err != nil {
+				return err
+			}
+			if err := updateComments(log, ghc, pr, emptySuite, finalComment); err != nil {
+				return err
+			}
+			if err := updateStatus(log, ghc, pr, emptySuite, state); err != nil {
+				return err
+			}
+			return nil
+		}
+		if statusErr := updateStatus(log, ghc, pr, suite.NewPRSuite(&suite.PullRequest{PullRequestQuery: *pr}), "pending"); statusErr != nil {
+			log.Printf("PR (%v) failed to set a pending status before retrying, %v\n", int(pr.Number), statusErr)
+		}
+		return err
+	}
+	prSuite.MetadataFolder = h.metadataFolder()
+	prSuite.LastSupportingVersionsOverride = supportedReleasesForOrg(string(pr.Repository.Owner.Login))
+	prSuite.SetSubmissionMetadatafromFolderStructure()
+	titleMatch := matchConformancePRTitle(string(pr.Title))
+	// a PR adding a PRODUCT.yaml is most likely a conformance submission
+	// whose title is just unusual, so the checks still run with an advisory
+	// about the title rather than the submission being rejected outright
+	titleAdvisory := false
+	if !titleMatch.IsConformance {
+		if prSuite.GetFileByFileName("PRODUCT.yaml") != nil {
+			log.Printf("This PR (%v) has a nonconforming title but adds a PRODUCT.yaml; treating it as a conformance PR\n", int(pr.Number))
+			titleAdvisory = true
+		}
+	}
+	// a submission targeting the wrong base branch can't merge as-is; the
+	// checks still run, with an advisory asking for a retarget
+	baseBranchAdvisory := expectedBaseBranch != "" && string(pr.BaseRef.Name) != "" && string(pr.BaseRef.Name) != expectedBaseBranch
+	if !titleMatch.IsConformance && !titleAdvisory {
+		log.Printf("This PR (%v) is not a conformance PR\n", int(pr.Number))
+		finalComment := strings.Join(
+			[]string{
+				"This pull request appears to not be a conformance results submission; Checks will not run.",
+				"",
+				"If this change is intended to be verified as a conformance results submission see: " +
+					"[_content of the PR_](https://github.com/cncf/k8s-conformance/blob/master/instructions.md#contents-of-the-pr), " +
+					"and [_requirements_](https://github.com/cncf/k8s-conformance/blob/master/instructions.md#requirements)",
+			},
+			"\n")
+		labels := []string{"not-conformance-product-submission", "unable-to-process"}
+		state := "pending"
+		if _, _, err := updateLabels(log, ghc, pr, prSuite, labels); err != nil {
+			return err
+		}
+		if err := updateComments(log, ghc, pr, prSuite, finalComment); err != nil {
+			return err
+		}
+		if err := updateStatus(log, ghc, pr, prSuite, state); err != nil {
+			return err
+		}
+		return nil
+	}
+	if titleMatch.Version != "" {
+		log.Infof("PR (%v) title declares version '%v' for product '%v'", int(pr.Number), titleMatch.Version, titleMatch.Product)
+	}
+
+	if requiredAcknowledgment != "" && !strings.Contains(strings.ToLower(string(pr.Body)), strings.ToLower(requiredAcknowledgment)) {
+		log.Printf("PR (%v) is missing the required acknowledgment\n", int(pr.Number))
+		finalComment := strings.Join(
+			[]string{
+				"This pull request is missing the required acknowledgment; Checks will not run until the pull request description contains the following phrase:",
+				"",
+				fmt.Sprintf("> %v", requiredAcknowledgment),
+			},
+			"\n")
+		labels := []string{"conformance-product-submission", "acknowledgment-missing"}
+		state := "pending"
+		if _, _, err := updateLabels(log, ghc, pr, prSuite, labels); err != nil {
+			return err
+		}
+		if err := updateComments(log, ghc, pr, prSuite, finalComment); err != nil {
+			return err
+		}
+		if err := updateStatus(log, ghc, pr, prSuite, state); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if prSuite.HasNoFilesChanged {
+		log.Printf("PR (%v) changes no files\n", int(pr.Number))
+		finalComment := strings.Join(
+			[]string{
+				"This pull request changes no files; Checks will not run until the submission files are added.",
+				"",
+				"For the files a submission must include see: " +
+					"[_content of the PR_](https://github.com/cncf/k8s-conformance/blob/master/instructions.md#contents-of-the-pr)",
+			},
+			"\n")
+		labels := []string{"conformance-product-submission", "unable-to-process"}
+		state := "pending"
+		if _, _, err := updateLabels(log, ghc, pr, prSuite, labels); err != nil {
+			return err
+		}
+		if err := updateComments(log, ghc, pr, prSuite, finalComment); err != nil {
+			return err
+		}
+		if err := updateStatus(log, ghc, pr, prSuite, state); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if prSuite.ProductYAMLIsEmpty {
+		log.Printf("PRODUCT.yaml in PR (%v) is empty\n", int(pr.Number))
+		finalComment := strings.Join(
+			[]string{
+				"The PRODUCT.yaml file in this submission is empty; Checks will not run until it is filled in.",
+				"",
+				"For the required fields see: " +
+					"[_contents of the PRODUCT.yaml_](https://github.com/cncf/k8s-conformance/blob/master/instructions.md#contents-of-the-productyaml)",
+			},
+			"\n")
+		labels := []string{"conformance-product-submission", "unable-to-process"}
+		state := "pending"
+		if _, _, err := updateLabels(log, ghc, pr, prSuite, labels); err != nil {
+			return err
 		}
-		for _, context := range commit.Commit.Status.Contexts {
-			if strings.EqualFold(string(context.Context), "verify-conformance") {
-				currentLatestHasCurrentStatus = strings.EqualFold(string(context.State), string(githubql.StatusStateSuccess))
-				break commitLoop
-			}
+		if err := updateComments(log, ghc, pr, prSuite, finalComment); err != nil {
+			return err
+		}
+		if err := updateStatus(log, ghc, pr, prSuite, state); err != nil {
+			return err
 		}
-	}
-	if currentLatestHasCurrentStatus {
-		log.Infof("PR %v has status up to date", pr.Number)
-		return nil
-	}
-	switch state {
-	case "success":
-		description = "All checks are passing"
-	case "failure":
-		description = "Please check failing requirements and update accordingly"
-	default:
-		description = "Internal error"
-		log.Infof("PR %v has invalid state", pr.Number)
-	}
-	log.Infof("PR %v setting state of '%v' with description '%v'", pr.Number, state, description)
-	cs, err := ghc.GetCombinedStatus(string(pr.Repository.Owner.Login), string(pr.Repository.Name), string(pr.HeadRefOID))
-	if err != nil {
-		log.Infof("PR %v failed to get combined status: %v", pr.Number, err)
-		return err
-	}
-	if cs.SHA == string(pr.HeadRefOID) && cs.State == state {
-		log.Infof("PR %v state unchanged", pr.Number)
 		return nil
 	}
-	if err := ghc.CreateStatus(
-		string(pr.Repository.Owner.Login), string(pr.Repository.Name), string(pr.HeadRefOID),
-		github.Status{
-			Context:     "verify-conformance",
-			State:       state,
-			Description: description,
-		}); err != nil {
-		log.Infof("PR %v failed to create status: %v", pr.Number, err)
-		return err
-	}
-	return nil
-}
 
-// handle checks a Conformance Certification PR to determine if the contents of the PR pass sanity checks.
-// Adds a comment to indicate whether or not the version in the PR title occurs in the supplied logs.
-func handle(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery) error {
-	godogFeaturePaths := GetGodogPaths()
-	prSuite, err := NewPRSuiteForPR(log, ghc, pr)
-	if err != nil {
-		return err
+	// a submission mixing several version directories is ambiguous for every
+	// later check, so reject it before running the full suite
+	versionDirectories := prSuite.VersionDirectoriesInFiles()
+	conflictingVersions := []string{}
+	for _, version := range versionDirectories {
+		if titleMatch.Version != "" && version != titleMatch.Version {
+			conflictingVersions = append(conflictingVersions, version)
+		}
 	}
-	prSuite.MetadataFolder = path.Join(common.GetDataPath(), "conformance-testdata")
-	prSuite.SetSubmissionMetadatafromFolderStructure()
-	if !isConformancePR(pr) {
-		log.Printf("This PR (%v) is not a conformance PR\n", int(pr.Number))
+	if len(versionDirectories) > 1 || len(conflictingVersions) > 0 {
+		log.Printf("PR (%v) references multiple or conflicting version directories (%v)\n", int(pr.Number), strings.Join(versionDirectories, ", "))
 		finalComment := strings.Join(
 			[]string{
-				"This pull request appears to not be a conformance results submission; Checks will not run.",
+				fmt.Sprintf("This pull request references more than one Kubernetes release version (%v); a submission must target a single release matching its title.", strings.Join(versionDirectories, ", ")),
 				"",
-				"If this change is intended to be verified as a conformance results submission see: " +
-					"[_content of the PR_](https://github.com/cncf/k8s-conformance/blob/master/instructions.md#contents-of-the-pr), " +
-					"and [_requirements_](https://github.com/cncf/k8s-conformance/blob/master/instructions.md#requirements)",
+				"Please split the submission into one pull request per release, see: " +
+					"[_content of the PR_](https://github.com/cncf/k8s-conformance/blob/master/instructions.md#contents-of-the-pr)",
 			},
 			"\n")
-		labels := []string{"not-conformance-product-submission", "unable-to-process"}
+		if len(versionDirectories) == 1 {
+			finalComment = strings.Join(
+				[]string{
+					fmt.Sprintf("The files in this pull request are placed under the version directory %v, but the title declares version %v; Checks will not run until they match.", versionDirectories[0], titleMatch.Version),
+					"",
+					"For the expected layout see: " +
+						"[_content of the PR_](https://github.com/cncf/k8s-conformance/blob/master/instructions.md#contents-of-the-pr)",
+				},
+				"\n")
+		}
+		labels := []string{"conformance-product-submission", "unable-to-process"}
 		state := "pending"
 		if _, _, err := updateLabels(log, ghc, pr, prSuite, labels); err != nil {
 			return err
@@ -560,10 +2275,40 @@ func handle(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery) err
 		return nil
 	}
 
+	// fail closed when no feature files are found; running godog with no
+	// scenarios would report every requirement as passing
+	if len(godogFeaturePaths) == 0 {
+		finalComment := "The verifier is currently unable to load its checks; Please wait while this is resolved."
+		labels := []string{"conformance-product-submission", "unable-to-process"}
+		state := "pending"
+		if _, _, err := updateLabels(log, ghc, pr, prSuite, labels); err != nil {
+			return err
+		}
+		if err := updateComments(log, ghc, pr, prSuite, finalComment); err != nil {
+			return err
+		}
+		if err := updateStatus(log, ghc, pr, prSuite, state); err != nil {
+			return err
+		}
+		return fmt.Errorf("unable to find any feature files to run checks with")
+	}
+
+	// show an in-progress status while the suite runs; the final state
+	// overwrites it once the checks complete
+	if err := updateStatus(log, ghc, pr, prSuite, "pending"); err != nil {
+		return err
+	}
+
 	if err := prSuite.ItIsAValidAndSupportedRelease(); err != nil {
 		finalComment := err.Error()
 		finalComment = fmt.Sprintf("%v.", strings.ToUpper(finalComment[:1])+finalComment[1:])
 		labels := []string{"conformance-product-submission", "unable-to-process"}
+		for _, l := range prSuite.Labels {
+			if l == "eol-version" {
+				labels = []string{"conformance-product-submission", "eol-version"}
+				break
+			}
+		}
 		state := "pending"
 		if _, _, err := updateLabels(log, ghc, pr, prSuite, labels); err != nil {
 			return err
@@ -576,10 +2321,9 @@ func handle(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery) err
 		}
 		return fmt.Errorf("unable to process release file as it is missing for release %v", prSuite.KubernetesReleaseVersion)
 	}
-	conformanceYAMLFilePath := path.Join(prSuite.MetadataFolder, prSuite.KubernetesReleaseVersion, "conformance.yaml")
-	if _, err := common.ReadFile(conformanceYAMLFilePath); err != nil && os.IsNotExist(err) {
-		finalComment := fmt.Sprintf("The release version %v is unable to be processed at this time; Please wait as this version may become available soon.", prSuite.KubernetesReleaseVersion)
-		labels := []string{"conformance-product-submission", "unable-to-process"}
+	if _, err := suite.LoadConformanceMetadata(prSuite.MetadataFolder, prSuite.KubernetesReleaseVersion); err != nil && os.IsNotExist(err) {
+		finalComment := fmt.Sprintf("The release version %v is valid, but its conformance metadata has not yet been published; the checks will run once it becomes available.", prSuite.KubernetesReleaseVersion)
+		labels := []string{"conformance-product-submission", "release-not-yet-available"}
 		state := "pending"
 		if _, _, err := updateLabels(log, ghc, pr, prSuite, labels); err != nil {
 			return err
@@ -591,8 +2335,17 @@ func handle(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery) err
 			return err
 		}
 		return fmt.Errorf("unable to process release file as it is missing for release %v", prSuite.KubernetesReleaseVersion)
+	} else if err != nil {
+		// corrupt metadata is an operator problem, not the submitter's;
+		// report it as an internal-error status without failing the
+		// submission
+		log.WithError(err).Errorf("unable to load the conformance metadata for release %v", prSuite.KubernetesReleaseVersion)
+		if statusErr := updateStatus(log, ghc, pr, prSuite, "error"); statusErr != nil {
+			return statusErr
+		}
+		return err
 	}
-	prSuite.NewTestSuite(suite.PRSuiteOptions{Paths: godogFeaturePaths}).Run()
+	prSuite.NewTestSuite(suite.PRSuiteOptions{Paths: godogFeaturePaths, Tags: featureTagFilterForOrg(string(pr.Repository.Owner.Login))}).Run()
 
 	finalComment, labels, state, err := prSuite.GetLabelsAndCommentsFromSuiteResultsBuffer()
 	if err != nil {
@@ -602,38 +2355,92 @@ func handle(log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery) err
 		log.Printf("There is nothing new to comment on PR (%v)\n", int(prSuite.PR.Number))
 		return nil
 	}
+	if titleAdvisory {
+		finalComment = strings.TrimRight(finalComment, "\n") + "\n\nNote: the pull request title doesn't match the expected format ('Conformance results for vX.YY/ProductName'); it has been treated as a conformance submission because it adds a PRODUCT.yaml.\n"
+	}
+	if baseBranchAdvisory {
+		finalComment = strings.TrimRight(finalComment, "\n") + fmt.Sprintf("\n\nNote: this pull request targets the base branch '%v' rather than the expected '%v'; please retarget it so it can be merged once verified.\n", pr.BaseRef.Name, expectedBaseBranch)
+		labels = append(labels, "wrong-base-branch")
+	}
+	// the success message becomes part of the posted comment, so the dedup
+	// marker in updateComments keeps it from being re-posted on later ticks
+	if orgSuccessComment := successCommentForOrg(string(pr.Repository.Owner.Login)); state == "success" && orgSuccessComment != "" {
+		finalComment = strings.TrimRight(finalComment, "\n") + "\n\n" + orgSuccessComment + "\n"
+	}
+	if attachJSONResults {
+		resultsJSON, err := prSuite.GetResultsJSON()
+		if err != nil {
+			log.WithError(err).Warn("unable to serialize the suite results to JSON")
+		} else {
+			finalComment += fmt.Sprintf("\n<details><summary>Machine-readable results</summary>\n\n```json\n%v\n```\n</details>\n", resultsJSON)
+		}
+	}
 
-	fmt.Printf("PR url: https://github.com/%v/%v/pull/%v \n", prSuite.PR.PullRequestQuery.Repository.Owner.Login, prSuite.PR.PullRequestQuery.Repository.Name, prSuite.PR.PullRequestQuery.Number)
-	fmt.Println("PR title:", prSuite.PR.Title)
-	fmt.Println("Release Version:", prSuite.KubernetesReleaseVersion)
-	fmt.Println("Labels:", strings.Join(labels, ", "))
-	fmt.Println(finalComment)
+	resultsLog := log.WithFields(logrus.Fields{
+		"org":     string(prSuite.PR.PullRequestQuery.Repository.Owner.Login),
+		"repo":    string(prSuite.PR.PullRequestQuery.Repository.Name),
+		"pr":      int(prSuite.PR.PullRequestQuery.Number),
+		"version": prSuite.KubernetesReleaseVersion,
+	})
+	resultsLog.Infof("PR url: https://github.com/%v/%v/pull/%v", prSuite.PR.PullRequestQuery.Repository.Owner.Login, prSuite.PR.PullRequestQuery.Repository.Name, prSuite.PR.PullRequestQuery.Number)
+	resultsLog.Infof("PR title: %v", prSuite.PR.Title)
+	resultsLog.Infof("labels: %v", strings.Join(labels, ", "))
+	resultsLog.Info(finalComment)
 
 	newLabels, removedLabels, err := updateLabels(log, ghc, pr, prSuite, labels)
 	if err != nil {
 		return err
 	}
-	fmt.Println("NewLabels: ", newLabels)
-	fmt.Println("RemovedLabels: ", removedLabels)
+	storeComputedLabels(pr, labels)
+	storeComputedComment(pr, finalComment)
+	storeComputedState(pr, state)
+	resultsLog.WithFields(logrus.Fields{
+		"addedLabels":   strings.Join(newLabels, ","),
+		"removedLabels": strings.Join(removedLabels, ","),
+	}).Info("reconciled labels")
 
 	if err := updateComments(log, ghc, pr, prSuite, finalComment); err != nil {
 		return err
 	}
+	if checksRunEnabled {
+		checkRunErr := createCheckRun(log, ghc, pr, prSuite, state, finalComment)
+		if checkRunErr == nil {
+			return nil
+		}
+		log.WithError(checkRunErr).Warn("unable to create a checks run; falling back to a commit status")
+	}
 	if err := updateStatus(log, ghc, pr, prSuite, state); err != nil {
 		return err
 	}
 	return nil
 }
 
-func NewPullRequestQueryForGithubPullRequest(orgName string, repoName string, number int, pr *github.PullRequest) *suite.PullRequestQuery {
+// NewPullRequestQueryForGithubPullRequest builds a query for a PR delivered
+// in a webhook event. Partially-populated events are reported as an error
+// rather than panicking further in.
+func NewPullRequestQueryForGithubPullRequest(orgName string, repoName string, number int, pr *github.PullRequest) (*suite.PullRequestQuery, error) {
+	if pr == nil {
+		return nil, fmt.Errorf("unable to build a query for PR (%v); the event contains no pull request", number)
+	}
+	if orgName == "" || repoName == "" {
+		return nil, fmt.Errorf("unable to build a query for PR (%v); the event names no repository", number)
+	}
 	return &suite.PullRequestQuery{
-		Title:  githubql.String(pr.Title),
-		Number: githubql.Int(number),
+		Title:      githubql.String(pr.Title),
+		Body:       githubql.String(pr.Body),
+		Number:     githubql.Int(number),
+		HeadRefOID: githubql.String(pr.Head.SHA),
+		IsDraft:    githubql.Boolean(pr.Draft),
 		Author: struct {
 			Login githubql.String
 		}{
 			Login: githubql.String(pr.User.Login),
 		},
+		BaseRef: struct {
+			Name githubql.String
+		}{
+			Name: githubql.String(pr.Base.Ref),
+		},
 		Repository: struct {
 			Name  githubql.String
 			Owner struct {
@@ -644,13 +2451,18 @@ func NewPullRequestQueryForGithubPullRequest(orgName string, repoName string, nu
 			Owner: struct {
 				Login githubql.String
 			}{
-				Login: githubql.String(pr.User.Login),
+				// the repository owner, not the PR author; fork PRs are
+				// authored by users outside the org
+				Login: githubql.String(orgName),
 			},
 		},
-	}
+	}, nil
 }
 
 func NewGitHubPullRequestForPullRequestQuery(orgName string, repoName string, number int, pr *suite.PullRequestQuery) *github.PullRequest {
+	if pr == nil {
+		return nil
+	}
 	return &github.PullRequest{
 		Title:  string(pr.Title),
 		Number: number,
@@ -663,29 +2475,260 @@ func NewGitHubPullRequestForPullRequestQuery(orgName string, repoName string, nu
 // HandlePullRequestEvent handles a GitHub pull request event
 func HandlePullRequestEvent(log *logrus.Entry, ghc githubClient, pre *github.PullRequestEvent) error {
 	log.Infof("HandlePullRequestEvent")
+	if pre == nil {
+		return fmt.Errorf("unable to handle an empty pull request event")
+	}
 	switch pre.Action {
-	case github.PullRequestActionOpened, github.PullRequestActionReopened:
+	case github.PullRequestActionOpened, github.PullRequestActionReopened, github.PullRequestActionSynchronize, github.PullRequestActionReadyForReview:
 	default:
 		return nil
 	}
 
-	return handle(log, ghc, NewPullRequestQueryForGithubPullRequest(pre.Repo.Owner.Login, pre.Repo.Name, pre.Number, &pre.PullRequest))
+	prQuery, err := NewPullRequestQueryForGithubPullRequest(pre.Repo.Owner.Login, pre.Repo.Name, pre.Number, &pre.PullRequest)
+	if err != nil {
+		return err
+	}
+	return handle(context.Background(), log, ghc, prQuery)
+}
+
+// reports whether an issue comment body contains the given command on a line
+// of its own
+func commentContainsCommand(body, command string) bool {
+	for _, line := range strings.Split(body, "\n") {
+		if strings.TrimSpace(line) == command {
+			return true
+		}
+	}
+	return false
+}
+
+// reports whether an issue comment body contains the recheck command on a
+// line of its own
+func commentRequestsRecheck(body string) bool {
+	return commentContainsCommand(body, recheckCommand)
+}
+
+// returns the check name given to the override command when an issue comment
+// body contains it on a line of its own, and whether it was found
+func commentRequestsOverride(body string) (string, bool) {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, overrideCommand+" ") {
+			continue
+		}
+		if checkName := strings.TrimSpace(strings.TrimPrefix(line, overrideCommand)); checkName != "" {
+			return checkName, true
+		}
+	}
+	return "", false
+}
+
+// relabel restores any managed labels missing from a PR using the label set
+// most recently computed for its head commit, without re-fetching artifacts
+// or re-running the suite. When no label set has been recorded for the head,
+// a full run is performed instead.
+func relabel(ctx context.Context, log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery) error {
+	labels, ok := computedLabelsForPullRequest(pr)
+	if !ok {
+		log.Printf("no label set recorded for PR (%v) at head '%v'; running a full check\n", int(pr.Number), pr.HeadRefOID)
+		return handle(ctx, log, ghc, pr)
+	}
+	org := string(pr.Repository.Owner.Login)
+	repo := string(pr.Repository.Name)
+	number := int(pr.Number)
+	issueLabels, err := ghc.GetIssueLabels(org, repo, number)
+	if err != nil {
+		return fmt.Errorf("unable to list labels on PR (%v), %v", number, err)
+	}
+labels:
+	for _, l := range labels {
+		for _, il := range issueLabels {
+			if il.Name == l {
+				continue labels
+			}
+		}
+		if dryRun {
+			log.Infof("dry-run: would restore label '%v' on %v/%v!%v", l, org, repo, number)
+			continue labels
+		}
+		if err := ghc.AddLabel(org, repo, number, l); err != nil {
+			return fmt.Errorf("failed to restore label '%v' on %v/%v!%v", l, org, repo, number)
+		}
+		log.Printf("restored label '%v' on PR (%v)\n", l, number)
+		metrics.LabelsAddedTotal.Inc()
+	}
+	return nil
+}
+
+// resetLabels removes every label the plugin manages from a PR so the next
+// run rebuilds the label state cleanly. Unmanaged labels are left in place,
+// and a confirmation comment lists what was removed.
+func resetLabels(ctx context.Context, log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery) error {
+	org := string(pr.Repository.Owner.Login)
+	repo := string(pr.Repository.Name)
+	number := int(pr.Number)
+	issueLabels, err := ghc.GetIssueLabels(org, repo, number)
+	if err != nil {
+		return fmt.Errorf("unable to list labels on PR (%v), %v", number, err)
+	}
+	removedLabels := []string{}
+	for _, l := range issueLabels {
+		if !labelIsManaged(l.Name) && !labelIsVersionLabel(l.Name, "") && !labelIsFileLabel(l.Name, nil) {
+			continue
+		}
+		if dryRun {
+			log.Infof("dry-run: would remove label '%v' from %v/%v!%v", l.Name, org, repo, number)
+			removedLabels = append(removedLabels, l.Name)
+			continue
+		}
+		if err := ghc.RemoveLabel(org, repo, number, l.Name); err != nil {
+			return fmt.Errorf("failed to remove label '%v' from %v/%v!%v", l.Name, org, repo, number)
+		}
+		log.Printf("removed label '%v' from PR (%v)\n", l.Name, number)
+		removedLabels = append(removedLabels, l.Name)
+	}
+	if len(removedLabels) == 0 {
+		log.Printf("no managed labels to remove from PR (%v)\n", number)
+		return nil
+	}
+	comment := fmt.Sprintf("Removed %v managed label(s) by request: %v. Comment '%v' to run the checks and rebuild them.", len(removedLabels), strings.Join(removedLabels, ", "), recheckCommand)
+	if dryRun {
+		log.Infof("dry-run: would comment on PR (%v): %v", number, comment)
+		return nil
+	}
+	if err := ghc.CreateComment(org, repo, number, comment); err != nil {
+		return fmt.Errorf("unable to post the reset confirmation on PR (%v), %v", number, err)
+	}
+	return nil
+}
+
+// overrideAdvisoryCheck records a maintainer override of the named advisory
+// check on a PR by applying its override label, so subsequent runs treat the
+// advisory as satisfied. Blocking checks cannot be overridden; attempting to
+// is refused with a comment.
+func overrideAdvisoryCheck(ctx context.Context, log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery, checkName string, commenter string) error {
+	org := string(pr.Repository.Owner.Login)
+	repo := string(pr.Repository.Name)
+	number := int(pr.Number)
+	if !suite.RequirementIsAdvisory(checkName) {
+		comment := fmt.Sprintf("The check '%v' is not an advisory check and cannot be overridden; only advisory checks may be skipped.", checkName)
+		if dryRun {
+			log.Infof("dry-run: would comment on PR (%v): %v", number, comment)
+			return nil
+		}
+		if err := ghc.CreateComment(org, repo, number, comment); err != nil {
+			return fmt.Errorf("unable to post the override refusal on PR (%v), %v", number, err)
+		}
+		return nil
+	}
+	label := suite.AdvisoryOverrideLabel(checkName)
+	comment := fmt.Sprintf("Advisory check '%v' has been overridden by @%v. Comment '%v' to re-run the checks with the override applied.", checkName, commenter, recheckCommand)
+	if dryRun {
+		log.Infof("dry-run: would add label '%v' and comment on PR (%v): %v", label, number, comment)
+		return nil
+	}
+	if err := ghc.AddLabel(org, repo, number, label); err != nil {
+		return fmt.Errorf("failed to add label '%v' to %v/%v!%v", label, org, repo, number)
+	}
+	log.Printf("added label '%v' to PR (%v)\n", label, number)
+	metrics.LabelsAddedTotal.Inc()
+	if err := ghc.CreateComment(org, repo, number, comment); err != nil {
+		return fmt.Errorf("unable to post the override confirmation on PR (%v), %v", number, err)
+	}
+	return nil
 }
 
-// HandleIssueCommentEvent handles a GitHub issue comment event and adds or removes a
-// message indicating that there are inconsitencies in the version of Kubernetes
-// referenced in the title of the PR versus the log file evidence supplied in the associated commit.
+// HandleIssueCommentEvent handles a GitHub issue comment event and re-runs the
+// suite when the comment contains the recheck command, or restores managed
+// labels when it contains the relabel command, or re-posts the latest result
+// when it contains the status command, when issued by the PR author or an org
+// member. The reset-labels and override commands are restricted to org
+// members. All other comments are ignored.
 func HandleIssueCommentEvent(log *logrus.Entry, ghc githubClient, ice *github.IssueCommentEvent) error {
 	log.Infof("HandleIssueCommentEvent")
+	if ice == nil {
+		return fmt.Errorf("unable to handle an empty issue comment event")
+	}
 	if !ice.Issue.IsPullRequest() {
 		return nil
 	}
+	requestsRecheck := commentRequestsRecheck(ice.Comment.Body)
+	requestsRelabel := commentContainsCommand(ice.Comment.Body, relabelCommand)
+	requestsResetLabels := commentContainsCommand(ice.Comment.Body, resetLabelsCommand)
+	requestsStatus := commentContainsCommand(ice.Comment.Body, statusCommand)
+	overrideCheck, requestsOverride := commentRequestsOverride(ice.Comment.Body)
+	if !requestsRecheck && !requestsRelabel && !requestsResetLabels && !requestsStatus && !requestsOverride {
+		return nil
+	}
+	commenter := ice.Comment.User.Login
+	if requestsResetLabels || requestsOverride {
+		// resetting the labels and overriding an advisory check are
+		// administrative actions, so org membership is required even of the
+		// PR author
+		member, err := ghc.IsMember(ice.Repo.Owner.Login, commenter)
+		if err != nil {
+			return fmt.Errorf("unable to determine org membership of '%v', %v", commenter, err)
+		}
+		if !member {
+			log.Printf("ignoring administrative request from '%v' on PR (%v); not an org member\n", commenter, ice.Issue.Number)
+			return nil
+		}
+	} else if commenter != ice.Issue.User.Login {
+		member, err := ghc.IsMember(ice.Repo.Owner.Login, commenter)
+		if err != nil {
+			return fmt.Errorf("unable to determine org membership of '%v', %v", commenter, err)
+		}
+		if !member {
+			log.Printf("ignoring recheck request from '%v' on PR (%v); not the author or an org member\n", commenter, ice.Issue.Number)
+			return nil
+		}
+	}
 	pr, err := ghc.GetPullRequest(ice.Repo.Owner.Login, ice.Repo.Name, ice.Issue.Number)
 	if err != nil {
 		return err
 	}
 
-	return handle(log, ghc, NewPullRequestQueryForGithubPullRequest(ice.Repo.Owner.Login, ice.Repo.Name, ice.Issue.Number, pr))
+	prQuery, err := NewPullRequestQueryForGithubPullRequest(ice.Repo.Owner.Login, ice.Repo.Name, ice.Issue.Number, pr)
+	if err != nil {
+		return err
+	}
+	if requestsOverride {
+		return overrideAdvisoryCheck(context.Background(), log, ghc, prQuery, overrideCheck, commenter)
+	}
+	if requestsResetLabels {
+		return resetLabels(context.Background(), log, ghc, prQuery)
+	}
+	if requestsStatus && !requestsRecheck {
+		return showStatus(context.Background(), log, ghc, prQuery)
+	}
+	if requestsRelabel && !requestsRecheck {
+		return relabel(context.Background(), log, ghc, prQuery)
+	}
+	// an explicit recheck re-runs the suite even for an already-verified head
+	invalidateComputedResults(prQuery)
+	return handle(context.Background(), log, ghc, prQuery)
+}
+
+// showStatus re-posts the result comment most recently rendered for the head
+// commit of a PR, without re-fetching artifacts or re-running the suite. When
+// no result has been recorded for the head, a full run is performed instead.
+func showStatus(ctx context.Context, log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery) error {
+	comment, ok := computedCommentForPullRequest(pr)
+	if !ok {
+		log.Printf("no result recorded for PR (%v) at head '%v'; running a full check\n", int(pr.Number), pr.HeadRefOID)
+		return handle(ctx, log, ghc, pr)
+	}
+	org := string(pr.Repository.Owner.Login)
+	repo := string(pr.Repository.Name)
+	number := int(pr.Number)
+	if dryRun {
+		log.Infof("dry-run: would comment on PR (%v): %v", number, comment)
+		return nil
+	}
+	if err := ghc.CreateComment(org, repo, number, comment); err != nil {
+		return fmt.Errorf("unable to post the current status on PR (%v), %v", number, err)
+	}
+	return nil
 }
 
 // HandleAll is called periodically and the period is setup in main.go
@@ -700,6 +2743,15 @@ func HandleIssueCommentEvent(log *logrus.Entry, ghc githubClient, ice *github.Is
 // if there is an inconsistency we add a comment that explains the problem
 // and tells the PR submitter to review the documentation
 func HandleAll(log *logrus.Entry, ghc githubClient, config *plugins.Configuration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), handleAllTimeout)
+	defer cancel()
+	return handleAll(ctx, log, ghc, config)
+}
+
+// handleAll runs a scan of all open PRs, stopping cleanly when the context is
+// cancelled or its deadline passes; PRs not yet handled are logged and
+// skipped, while PRs already handled stay handled
+func handleAll(ctx context.Context, log *logrus.Entry, ghc githubClient, config *plugins.Configuration) error {
 	log.Infof("%v : HandleAll : Checking all PRs for handling", PluginName)
 
 	orgs, repos := config.EnabledReposForExternalPlugin(PluginName) // TODO : Overkill see below
@@ -714,31 +2766,267 @@ func HandleAll(log *logrus.Entry, ghc githubClient, config *plugins.Configuratio
 	//	fmt.Fprint(&queryOpenPRs, "archived:false is:pr is:open -label:verifiable")
 	fmt.Fprint(&queryOpenPRs, "archived:false is:pr is:open ")
 	for _, repo := range repos {
-		slashSplit := strings.Split(repo, "/")
-		if n := len(slashSplit); n != 2 {
+		org, orgRepo, ok := orgRepoFromConfiguredRepo(repo)
+		if !ok {
 			log.WithField("repo", repo).Warn("Found repo that was not in org/repo format, ignoring...")
 			continue
 		}
-		org := slashSplit[0]
 		orgs = append(orgs, org)
-		fmt.Fprintf(&queryOpenPRs, " repo:\"%s\"", repo)
+		fmt.Fprintf(&queryOpenPRs, " repo:\"%s\"", orgRepo)
+	}
+	// an org configured several times, such as through multiple of its
+	// repos, is only swept once
+	seenOrgs := map[string]bool{}
+	uniqueOrgs := []string{}
+	for _, org := range orgs {
+		if seenOrgs[org] {
+			continue
+		}
+		seenOrgs[org] = true
+		uniqueOrgs = append(uniqueOrgs, org)
 	}
 
 	prs := []suite.PullRequestQuery{}
-	for _, org := range orgs {
-		prSearch, err := search(context.Background(), log, ghc, queryOpenPRs.String(), org)
+	seenPRs := map[string]bool{}
+	for _, org := range uniqueOrgs {
+		prSearch, truncated, err := search(ctx, log, ghc, queryOpenPRs.String(), org)
 		if err != nil {
 			return err
 		}
-		prs = append(prs, prSearch...)
+		if truncated {
+			log.Warnf("HandleAll : the search sweep for org %v was truncated by rate limiting; handling the PRs found so far", org)
+		}
+		for _, pr := range prSearch {
+			key := fmt.Sprintf("%v/%v#%v", pr.Repository.Owner.Login, pr.Repository.Name, pr.Number)
+			if seenPRs[key] {
+				continue
+			}
+			seenPRs[key] = true
+			prs = append(prs, pr)
+		}
 	}
 	log.Infof("Considering %d PRs.", len(prs))
 
-	for _, pr := range prs {
-		err := handle(log, ghc, &pr)
+	handlePRsConcurrently(prs, func(pr *suite.PullRequestQuery) {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			log.Warnf("HandleAll : skipping PR (%v); %v", int(pr.Number), ctxErr)
+			return
+		}
+		err := handle(ctx, log, ghc, pr)
+		// an expired installation token fails every remaining PR the same
+		// way; refresh the credentials through the client's hook and give
+		// the PR one more attempt before recording the failure
+		if ra, ok := ghc.(reAuthenticator); ok && isCredentialError(err) {
+			log.Warnf("HandleAll : credential error handling PR (%v); re-authenticating and retrying, %v", int(pr.Number), err)
+			if raErr := ra.ReAuthenticate(); raErr != nil {
+				log.WithError(raErr).Error("HandleAll : unable to re-authenticate the github client")
+			} else {
+				err = handle(ctx, log, ghc, pr)
+			}
+		}
 		if err != nil {
 			log.Infof("error running checks on PR: %v", err)
 		}
+		if err := checkStaleSubmission(ctx, log, ghc, pr); err != nil {
+			log.Infof("error checking PR for staleness: %v", err)
+		}
+	})
+	if err := ctx.Err(); err != nil {
+		log.WithError(err).Warn("HandleAll : scan ended before all PRs were handled")
+	}
+	return nil
+}
+
+// checkStaleSubmission posts a single reminder comment on a PR that has
+// carried a failing label for longer than staleSubmissionAge, judged by the
+// timestamp of the last bot comment. The stale-reminder-sent label records
+// that the nudge was sent so it is not repeated on later scans.
+func checkStaleSubmission(ctx context.Context, log *logrus.Entry, ghc githubClient, pr *suite.PullRequestQuery) error {
+	hasFailingLabel := false
+	for _, l := range pr.Labels.Nodes {
+		if string(l.Name) == staleReminderLabel {
+			return nil
+		}
+		for _, failing := range failingPRLabels {
+			if string(l.Name) == failing {
+				hasFailingLabel = true
+			}
+		}
+	}
+	if !hasFailingLabel {
+		return nil
+	}
+	comments, err := githubClient.ListIssueCommentsWithContext(ghc, ctx, string(pr.Repository.Owner.Login), string(pr.Repository.Name), int(pr.Number))
+	if err != nil {
+		return fmt.Errorf("unable to list comments, %v", err)
+	}
+	botUserChecker, err := githubClient.BotUserChecker(ghc)
+	if err != nil {
+		return fmt.Errorf("unable to get bot name, %v", err)
+	}
+	var lastBotCommentAt time.Time
+	for _, c := range comments {
+		if !botUserChecker(c.User.Login) || c.Body == "" {
+			continue
+		}
+		if c.CreatedAt.After(lastBotCommentAt) {
+			lastBotCommentAt = c.CreatedAt
+		}
+	}
+	if lastBotCommentAt.IsZero() || time.Since(lastBotCommentAt) < staleSubmissionAge {
+		return nil
+	}
+	reminder := fmt.Sprintf(
+		"This conformance results submission has been failing its checks for over %v days. "+
+			"Please address the open requirements above, or comment '%v' to run the checks again after updating the submission.",
+		int(staleSubmissionAge.Hours()/24), recheckCommand)
+	if dryRun {
+		log.Infof("dry-run: would post a stale submission reminder on PR (%v)", int(pr.Number))
+		return nil
+	}
+	if err := githubClient.CreateComment(ghc, string(pr.Repository.Owner.Login), string(pr.Repository.Name), int(pr.Number), reminder); err != nil {
+		return fmt.Errorf("unable to post a stale submission reminder on PR (%v), %v", int(pr.Number), err)
 	}
+	if err := githubClient.AddLabel(ghc, string(pr.Repository.Owner.Login), string(pr.Repository.Name), int(pr.Number), staleReminderLabel); err != nil {
+		return fmt.Errorf("failed to add label '%v' to %v/%v!%v", staleReminderLabel, pr.Repository.Owner.Login, pr.Repository.Name, pr.Number)
+	}
+	metrics.LabelsAddedTotal.Inc()
 	return nil
 }
+
+// NewVerifyHandler returns an http.Handler that re-runs verification for a
+// single PR on demand, for operators debugging a stuck submission without
+// waiting for the next periodic scan. Requests must carry the shared-secret
+// token as "Authorization: Bearer <token>" and name the PR through the org,
+// repo, and number query parameters. The response summarises the labels on
+// the PR and the status of the run.
+func NewVerifyHandler(log *logrus.Entry, ghc githubClient, getToken func() []byte) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimSpace(string(getToken()))
+		presented := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		// compared in constant time so the comparison leaks nothing about
+		// the token's contents
+		if token == "" || subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		org := r.URL.Query().Get("org")
+		repo := r.URL.Query().Get("repo")
+		number, err := strconv.Atoi(r.URL.Query().Get("number"))
+		if org == "" || repo == "" || err != nil {
+			http.Error(w, "org, repo, and number are required", http.StatusBadRequest)
+			return
+		}
+		pr, err := ghc.GetPullRequest(org, repo, number)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("unable to fetch PR (%v), %v", number, err), http.StatusBadGateway)
+			return
+		}
+		prQuery, err := NewPullRequestQueryForGithubPullRequest(org, repo, number, pr)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		status := "success"
+		if err := handle(r.Context(), log, ghc, prQuery); err != nil {
+			status = err.Error()
+		}
+		labels := []string{}
+		issueLabels, err := ghc.GetIssueLabels(org, repo, number)
+		if err != nil {
+			log.WithError(err).Warnf("unable to fetch labels for PR (%v)", number)
+		}
+		for _, l := range issueLabels {
+			labels = append(labels, l.Name)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"org":    org,
+			"repo":   repo,
+			"number": number,
+			"labels": labels,
+			"status": status,
+		}); err != nil {
+			log.WithError(err).Warn("unable to encode the verify response")
+		}
+	})
+}
+
+// NewHealthzHandler returns an http.Handler reporting whether the plugin can
+// find its feature files, conformance metadata, and the latest stable
+// version — the misconfigurations that would otherwise pass PRs silently.
+// The response lists the latest stable version and the release directories
+// with metadata available, and carries a 503 status when any check fails.
+func NewHealthzHandler(log *logrus.Entry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		healthy := true
+
+		featurePaths := GetGodogPaths()
+		if len(featurePaths) == 0 {
+			healthy = false
+		}
+
+		metadataFolder := path.Join(common.GetDataPath(), "conformance-testdata")
+		metadataError := ""
+		releaseDirectories := []string{}
+		entries, err := os.ReadDir(metadataFolder)
+		if err != nil {
+			metadataError = err.Error()
+		} else {
+			for _, entry := range entries {
+				if entry.IsDir() {
+					releaseDirectories = append(releaseDirectories, entry.Name())
+				}
+			}
+		}
+		if metadataError != "" || len(releaseDirectories) == 0 {
+			healthy = false
+		}
+
+		stableTxtError := ""
+		latestStableVersion, err := common.GetStableTxt()
+		if err != nil {
+			stableTxtError = err.Error()
+			healthy = false
+		}
+
+		status := http.StatusOK
+		if !healthy {
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"healthy":             healthy,
+			"featurePaths":        featurePaths,
+			"metadataFolder":      metadataFolder,
+			"metadataError":       metadataError,
+			"releaseDirectories":  releaseDirectories,
+			"latestStableVersion": latestStableVersion,
+			"stableTxtError":      stableTxtError,
+		}); err != nil {
+			log.WithError(err).Warn("unable to encode the healthz response")
+		}
+	})
+}
+
+// runs fn for each PR with at most handleAllConcurrency invocations in
+// flight, pausing handleAllStagger between dispatches
+func handlePRsConcurrently(prs []suite.PullRequestQuery, fn func(pr *suite.PullRequestQuery)) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, handleAllConcurrency)
+	for i := range prs {
+		if handleAllStagger > 0 && i > 0 {
+			sleep(handleAllStagger)
+		}
+		pr := prs[i]
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(&pr)
+		}()
+	}
+	wg.Wait()
+}
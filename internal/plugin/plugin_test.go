@@ -17,15 +17,25 @@ limitations under the License.
 package plugin
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	_ "embed"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"sigs.k8s.io/verify-conformance/internal/common"
 	"sigs.k8s.io/verify-conformance/internal/suite"
@@ -45,16 +55,33 @@ var (
 	testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01xml string
 )
 
+// TestMain allowlists the loopback hosts used by the httptest servers, which
+// the PRODUCT.yaml URL host policy otherwise refuses as non-public
+func TestMain(m *testing.M) {
+	SetProductYAMLURLHostPolicy([]string{"127.0.0.1", "localhost", "::1"}, nil)
+	os.Exit(m.Run())
+}
+
 type prContext struct {
 	PullRequestQuery *suite.PullRequestQuery
 	SupportingFiles  []*suite.PullRequestFile
 	Comments         []github.IssueComment
 	HeadRefOID       string
 	Status           github.Status
+	Statuses         []github.Status
+	RepoIsPrivate    bool
 }
 
 type FakeGitHubClient struct {
-	PopulatedPullRequests []*prContext
+	PopulatedPullRequests   []*prContext
+	CreatedCheckRuns        []githubql.CreateCheckRunInput
+	FailCheckRunCreation    bool
+	RateLimitRemaining      *int
+	RateLimitResetAt        *time.Time
+	SearchQueryCount        int
+	SearchedOrgs            []string
+	PullRequestChangesCalls []int
+	StaleCommentDeletions   int
 }
 
 func NewFakeGitHubClient(p []*prContext) *FakeGitHubClient {
@@ -75,23 +102,32 @@ func (f *FakeGitHubClient) CreateStatus(org string, repo string, headRefOID stri
 		}
 		f.PopulatedPullRequests[i].HeadRefOID = headRefOID
 		f.PopulatedPullRequests[i].Status = status
+		f.PopulatedPullRequests[i].Statuses = append(f.PopulatedPullRequests[i].Statuses, status)
 	}
 	return nil
 }
 func (f *FakeGitHubClient) GetCombinedStatus(org, repo, ref string) (*github.CombinedStatus, error) {
 	sha := ""
 	state := ""
+	statuses := []github.Status{}
 	for i := range f.PopulatedPullRequests {
 		if string(f.PopulatedPullRequests[i].PullRequestQuery.Repository.Owner.Login) == org &&
 			string(f.PopulatedPullRequests[i].PullRequestQuery.Repository.Name) == repo {
+			// the recorded statuses bind to the SHA they were created for; a
+			// lookup for a different ref finds nothing, as after a force-push
+			if f.PopulatedPullRequests[i].HeadRefOID != "" && ref != "" && f.PopulatedPullRequests[i].HeadRefOID != ref {
+				break
+			}
 			sha = f.PopulatedPullRequests[i].HeadRefOID
 			state = f.PopulatedPullRequests[i].Status.State
+			statuses = f.PopulatedPullRequests[i].Statuses
 			break
 		}
 	}
 	return &github.CombinedStatus{
-		SHA:   sha,
-		State: state,
+		SHA:      sha,
+		State:    state,
+		Statuses: statuses,
 	}, nil
 }
 func (f *FakeGitHubClient) GetIssueLabels(org, repo string, number int) ([]github.Label, error) {
@@ -160,7 +196,9 @@ func (f *FakeGitHubClient) RemoveLabel(org, repo string, number int, label strin
 	var prIndex *int
 	for i := range f.PopulatedPullRequests {
 		if f.PopulatedPullRequests[i].PullRequestQuery.Number == githubql.Int(number) {
-			prIndex = &i
+			index := i
+			prIndex = &index
+			break
 		}
 	}
 	if prIndex == nil {
@@ -169,7 +207,9 @@ func (f *FakeGitHubClient) RemoveLabel(org, repo string, number int, label strin
 	var labelIndex *int
 	for i, l := range f.PopulatedPullRequests[*prIndex].PullRequestQuery.Labels.Nodes {
 		if l.Name == githubql.String(label) {
-			labelIndex = &i
+			index := i
+			labelIndex = &index
+			break
 		}
 	}
 	if labelIndex == nil {
@@ -179,12 +219,17 @@ func (f *FakeGitHubClient) RemoveLabel(org, repo string, number int, label strin
 	return nil
 }
 func (f *FakeGitHubClient) DeleteStaleComments(org, repo string, number int, comments []github.IssueComment, isStale func(github.IssueComment) bool) error {
+	f.StaleCommentDeletions++
 	return nil
 }
 func (f *FakeGitHubClient) QueryWithGitHubAppsSupport(ctx context.Context, sq interface{}, vars map[string]interface{}, org string) error {
 	if org == "nil" {
 		return fmt.Errorf("org does not exist")
 	}
+	if repoQuery, ok := sq.(*RepositoryQuery); ok {
+		repoQuery.Repository.ID = githubql.ID("R_1")
+		return nil
+	}
 	// wrap each pull request in an array struct, as per search query nodes
 	if len(f.PopulatedPullRequests) > 0 && f.PopulatedPullRequests[0] == nil {
 		return fmt.Errorf("empty pr")
@@ -211,6 +256,8 @@ func (f *FakeGitHubClient) QueryWithGitHubAppsSupport(ctx context.Context, sq in
 	if !ok {
 		return fmt.Errorf("failed to case sq to SearchQuery")
 	}
+	f.SearchQueryCount++
+	f.SearchedOrgs = append(f.SearchedOrgs, org)
 	hasNextPage := false
 	// TODO tidy this
 	searchCursor := func() string {
@@ -229,13 +276,23 @@ func (f *FakeGitHubClient) QueryWithGitHubAppsSupport(ctx context.Context, sq in
 	} else {
 		searchCursor = "1"
 	}
+	remaining := 999999
+	if f.RateLimitRemaining != nil {
+		remaining = *f.RateLimitRemaining
+	}
+	resetAt := time.Time{}
+	if f.RateLimitResetAt != nil {
+		resetAt = *f.RateLimitResetAt
+	}
 	*query = SearchQuery{
 		RateLimit: struct {
 			Cost      githubql.Int
 			Remaining githubql.Int
+			ResetAt   githubql.DateTime
 		}{
 			Cost:      githubql.Int(1),
-			Remaining: githubql.Int(999999),
+			Remaining: githubql.Int(remaining),
+			ResetAt:   githubql.DateTime{Time: resetAt},
 		},
 		Search: struct {
 			PageInfo struct {
@@ -258,6 +315,15 @@ func (f *FakeGitHubClient) QueryWithGitHubAppsSupport(ctx context.Context, sq in
 	}
 	return nil
 }
+func (f *FakeGitHubClient) MutateWithGitHubAppsSupport(ctx context.Context, m interface{}, input githubql.Input, vars map[string]interface{}, org string) error {
+	if f.FailCheckRunCreation {
+		return fmt.Errorf("Resource not accessible by integration")
+	}
+	if checkRunInput, ok := input.(githubql.CreateCheckRunInput); ok {
+		f.CreatedCheckRuns = append(f.CreatedCheckRuns, checkRunInput)
+	}
+	return nil
+}
 func (f *FakeGitHubClient) GetPullRequest(org, repo string, number int) (*github.PullRequest, error) {
 	var prIndex *int
 	for i := range f.PopulatedPullRequests {
@@ -268,9 +334,25 @@ func (f *FakeGitHubClient) GetPullRequest(org, repo string, number int) (*github
 	if prIndex == nil {
 		return nil, fmt.Errorf("unable make comment '%v'", number)
 	}
-	return NewGitHubPullRequestForPullRequestQuery(org, repo, number, f.PopulatedPullRequests[*prIndex].PullRequestQuery), nil
+	pullRequest := NewGitHubPullRequestForPullRequestQuery(org, repo, number, f.PopulatedPullRequests[*prIndex].PullRequestQuery)
+	pullRequest.Base.Repo.Private = f.PopulatedPullRequests[*prIndex].RepoIsPrivate
+	return pullRequest, nil
+}
+func (f *FakeGitHubClient) GetFile(org, repo, filepath string, commit string) ([]byte, error) {
+	for _, pr := range f.PopulatedPullRequests {
+		for _, file := range pr.SupportingFiles {
+			if file.Name == filepath {
+				return []byte(file.Contents), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("unable to find file '%v' in %v/%v", filepath, org, repo)
+}
+func (f *FakeGitHubClient) IsMember(org, user string) (bool, error) {
+	return user == "org-member", nil
 }
 func (f *FakeGitHubClient) GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error) {
+	f.PullRequestChangesCalls = append(f.PullRequestChangesCalls, number)
 	pr := &prContext{}
 	prChanges := []github.PullRequestChange{}
 	for _, n := range f.PopulatedPullRequests {
@@ -280,9 +362,15 @@ func (f *FakeGitHubClient) GetPullRequestChanges(org, repo string, number int) (
 		}
 	}
 	for _, file := range pr.SupportingFiles {
+		status := file.Status
+		if status == "" {
+			status = github.PullRequestFileAdded
+		}
 		prChanges = append(prChanges, github.PullRequestChange{
 			Filename: file.Name,
 			BlobURL:  file.BlobURL,
+			SHA:      file.SHA,
+			Status:   status,
 		})
 	}
 	return prChanges, nil
@@ -307,7 +395,40 @@ func Test_fetchFileFromURI(t *testing.T) {
 		}
 	}))
 	defer svr.Close()
-	content, resp, err := fetchFileFromURI(svr.URL)
+	content, resp, err := fetchFileFromURI(context.TODO(), svr.URL)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if content != `Hello!` {
+		t.Fatalf("error: content doesn't match what is expected")
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("error: response code doesn't match what was expected")
+	}
+
+}
+
+func Test_fetchFileFromURIRetriesTransientFailures(t *testing.T) {
+	originalBackoff := fetchFileRetryBackoff
+	fetchFileRetryBackoff = time.Millisecond
+	defer func() {
+		fetchFileRetryBackoff = originalBackoff
+	}()
+
+	requestCount := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		if requestCount <= 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`Hello!`)); err != nil {
+			t.Fatalf("error: sending http response; %v", err)
+		}
+	}))
+	defer svr.Close()
+	content, resp, err := fetchFileFromURI(context.TODO(), svr.URL)
 	if err != nil {
 		t.Fatalf("error: %v", err)
 	}
@@ -317,7 +438,28 @@ func Test_fetchFileFromURI(t *testing.T) {
 	if resp.StatusCode != http.StatusOK {
 		t.Fatalf("error: response code doesn't match what was expected")
 	}
+	if requestCount != 3 {
+		t.Fatalf("error: expected 3 requests, got %v", requestCount)
+	}
+}
 
+func Test_fetchFileFromURIDoesNotRetryNotFound(t *testing.T) {
+	requestCount := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer svr.Close()
+	_, resp, err := fetchFileFromURI(context.TODO(), svr.URL)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("error: response code doesn't match what was expected")
+	}
+	if requestCount != 1 {
+		t.Fatalf("error: expected 1 request, got %v", requestCount)
+	}
 }
 
 func Test_rawURLForBlobURL(t *testing.T) {
@@ -338,6 +480,14 @@ func Test_rawURLForBlobURL(t *testing.T) {
 			BlobURL:           "https://github.com/cncf-infra/verify-conformance/blob/main/README.org",
 			RawUserContentURL: "https://raw.githubusercontent.com/cncf-infra/verify-conformance/main/README.org",
 		},
+		{
+			BlobURL:           "https://github.mycorp.com/mycorp/k8s-conformance/blob/2c25ea5963e88ad77a8035dc639c7e3a60b8fb0f/v1.27/coolkube/PRODUCT.yaml",
+			RawUserContentURL: "https://github.mycorp.com/mycorp/k8s-conformance/raw/2c25ea5963e88ad77a8035dc639c7e3a60b8fb0f/v1.27/coolkube/PRODUCT.yaml",
+		},
+		{
+			BlobURL:           "https://github.mycorp.com/some-org/nested-team/k8s-conformance/blob/main/v1.27/coolkube/e2e.log",
+			RawUserContentURL: "https://github.mycorp.com/some-org/nested-team/k8s-conformance/raw/main/v1.27/coolkube/e2e.log",
+		},
 	} {
 		output := rawURLForBlobURL(u.BlobURL)
 		if output != u.RawUserContentURL {
@@ -346,964 +496,1652 @@ func Test_rawURLForBlobURL(t *testing.T) {
 	}
 }
 
-func Test_search(t *testing.T) {
+func Test_decompressContentIfGzip(t *testing.T) {
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	if _, err := gzipWriter.Write([]byte("will run for conformance")); err != nil {
+		t.Fatalf("error: compressing content; %v", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatalf("error: closing gzip writer; %v", err)
+	}
 	type testCase struct {
-		Name                string
-		PullRequestQuery    *suite.PullRequestQuery
-		ExpectedErrorString string
+		Name            string
+		FileName        string
+		Content         string
+		ExpectedContent string
 	}
 	for _, tc := range []testCase{
 		{
-			Name: "complete result",
-			PullRequestQuery: &suite.PullRequestQuery{
-				Number: githubql.Int(1),
-				Author: struct{ Login githubql.String }{
-					Login: githubql.String("cncf"),
-				},
-				Repository: struct {
-					Name  githubql.String
-					Owner struct{ Login githubql.String }
-				}{
-					Name: githubql.String("k8s-conformance"),
-					Owner: struct{ Login githubql.String }{
-						Login: githubql.String("cncf"),
-					},
-				},
+			Name:            "plaintext file is unchanged",
+			FileName:        "e2e.log",
+			Content:         "will run for conformance",
+			ExpectedContent: "will run for conformance",
+		},
+		{
+			Name:            "gzip file by extension",
+			FileName:        "e2e.log.gz",
+			Content:         compressed.String(),
+			ExpectedContent: "will run for conformance",
+		},
+		{
+			Name:            "gzip file by magic bytes",
+			FileName:        "e2e.log",
+			Content:         compressed.String(),
+			ExpectedContent: "will run for conformance",
+		},
+	} {
+		content, err := decompressContentIfGzip(tc.FileName, tc.Content)
+		if err != nil {
+			t.Fatalf("error: decompressing content in testcase '%v'; %v", tc.Name, err)
+		}
+		if content != tc.ExpectedContent {
+			t.Fatalf("error: content (%v) in testcase '%v' doesn't match what is expected (%v)", content, tc.Name, tc.ExpectedContent)
+		}
+	}
+}
+
+func TestNewPRSuiteForPRWithGzippedFile(t *testing.T) {
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	if _, err := gzipWriter.Write([]byte("will run for conformance")); err != nil {
+		t.Fatalf("error: compressing content; %v", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatalf("error: closing gzip writer; %v", err)
+	}
+	common.DataPathPrefix = "../../"
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(compressed.Bytes()); err != nil {
+			t.Fatalf("error: sending http response; %v", err)
+		}
+	}))
+	defer svr.Close()
+	pullRequestQuery := &suite.PullRequestQuery{
+		Number: githubql.Int(1),
+		Repository: struct {
+			Name  githubql.String
+			Owner struct{ Login githubql.String }
+		}{
+			Name: githubql.String("cncf-ci"),
+			Owner: struct{ Login githubql.String }{
+				Login: githubql.String("cncf-ci"),
 			},
 		},
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
 		{
-			Name: "org does not exist",
-			PullRequestQuery: &suite.PullRequestQuery{
-				Number: githubql.Int(1),
-				Author: struct{ Login githubql.String }{
-					Login: githubql.String("nil"),
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles: []*suite.PullRequestFile{
+				{
+					Name:     "v1.30/coolkube/e2e.log.gz",
+					BaseName: "e2e.log.gz",
+					BlobURL:  svr.URL + "/e2e.log.gz",
 				},
-				Repository: struct {
-					Name  githubql.String
-					Owner struct{ Login githubql.String }
-				}{
-					Name: githubql.String("k8s-conformance"),
-					Owner: struct{ Login githubql.String }{
-						Login: githubql.String("nil"),
-					},
+			},
+		},
+	})
+	prSuite, err := NewPRSuiteForPR(log, ghc, pullRequestQuery)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if len(prSuite.PR.SupportingFiles) != 1 {
+		t.Fatalf("error: expected one supporting file, found %v", len(prSuite.PR.SupportingFiles))
+	}
+	file := prSuite.PR.SupportingFiles[0]
+	if file.Contents != "will run for conformance" {
+		t.Fatalf("error: contents (%v) doesn't match the expected decompressed text", file.Contents)
+	}
+	if file.BaseName != "e2e.log" {
+		t.Fatalf("error: base name (%v) should have the .gz suffix trimmed", file.BaseName)
+	}
+}
+
+// failingIssueLabelsFakeGitHubClient fails every issue label fetch, as a
+// GitHub API outage would
+type failingIssueLabelsFakeGitHubClient struct {
+	*FakeGitHubClient
+}
+
+func (f *failingIssueLabelsFakeGitHubClient) GetIssueLabels(org, repo string, number int) ([]github.Label, error) {
+	return nil, fmt.Errorf("the GitHub API is unavailable")
+}
+
+// failingChangesFakeGitHubClient fails every pull request changes fetch
+type failingChangesFakeGitHubClient struct {
+	*FakeGitHubClient
+}
+
+func (f *failingChangesFakeGitHubClient) GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error) {
+	return nil, fmt.Errorf("the GitHub API is unavailable")
+}
+
+func TestNewPRSuiteForPRErrorCategories(t *testing.T) {
+	common.DataPathPrefix = "../../"
+	originalBackoff := fetchFileRetryBackoff
+	fetchFileRetryBackoff = time.Millisecond
+	defer func() {
+		fetchFileRetryBackoff = originalBackoff
+	}()
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte("not actually gzip data")); err != nil {
+			t.Errorf("error: sending http response; %v", err)
+		}
+	}))
+	defer svr.Close()
+	unreachableSvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	unreachableURL := unreachableSvr.URL
+	unreachableSvr.Close()
+	type testCase struct {
+		Name             string
+		WrapClient       func(ghc *FakeGitHubClient) githubClient
+		SupportingFiles  []*suite.PullRequestFile
+		ExpectedCategory PRSuiteErrorCategory
+	}
+	for _, tc := range []testCase{
+		{
+			Name: "failing issue label fetch is transient",
+			WrapClient: func(ghc *FakeGitHubClient) githubClient {
+				return &failingIssueLabelsFakeGitHubClient{ghc}
+			},
+			ExpectedCategory: PRSuiteErrorTransient,
+		},
+		{
+			Name: "failing changes fetch is transient",
+			WrapClient: func(ghc *FakeGitHubClient) githubClient {
+				return &failingChangesFakeGitHubClient{ghc}
+			},
+			ExpectedCategory: PRSuiteErrorTransient,
+		},
+		{
+			Name: "unreachable file content is transient",
+			SupportingFiles: []*suite.PullRequestFile{
+				{
+					Name:     "v1.30/coolkube/e2e.log",
+					BaseName: "e2e.log",
+					BlobURL:  unreachableURL + "/e2e.log",
 				},
 			},
-			ExpectedErrorString: "org does not exist",
+			ExpectedCategory: PRSuiteErrorTransient,
 		},
 		{
-			Name:                "empty",
-			PullRequestQuery:    nil,
-			ExpectedErrorString: "empty pr",
+			Name: "corrupt gzip file is a content problem",
+			SupportingFiles: []*suite.PullRequestFile{
+				{
+					Name:     "v1.30/coolkube/e2e.log.gz",
+					BaseName: "e2e.log.gz",
+					BlobURL:  svr.URL + "/e2e.log.gz",
+				},
+			},
+			ExpectedCategory: PRSuiteErrorContent,
 		},
 	} {
-		ghc := NewFakeGitHubClient([]*prContext{
+		pullRequestQuery := &suite.PullRequestQuery{
+			Number: githubql.Int(1),
+			Repository: struct {
+				Name  githubql.String
+				Owner struct{ Login githubql.String }
+			}{
+				Name: githubql.String("cncf-ci"),
+				Owner: struct{ Login githubql.String }{
+					Login: githubql.String("cncf-ci"),
+				},
+			},
+		}
+		fake := NewFakeGitHubClient([]*prContext{
 			{
-				PullRequestQuery: tc.PullRequestQuery,
+				PullRequestQuery: pullRequestQuery,
+				SupportingFiles:  tc.SupportingFiles,
 			},
 		})
-		var org string
-		if tc.PullRequestQuery != nil {
-			org = string(tc.PullRequestQuery.Repository.Owner.Login)
+		var ghc githubClient = fake
+		if tc.WrapClient != nil {
+			ghc = tc.WrapClient(fake)
 		}
-		prs, err := search(context.TODO(), log, ghc, "archived:false is:pr is:open repo:\"k8s-conformance\"", org)
-		t.Logf("tc(%v) has error %v", tc.Name, err != nil)
-		if err != nil && !strings.Contains(err.Error(), tc.ExpectedErrorString) {
-			t.Fatalf("error searching for PRs: %v", err)
+		_, err := NewPRSuiteForPR(log, ghc, pullRequestQuery)
+		if err == nil {
+			t.Fatalf("error: expected an error in testcase '%v'", tc.Name)
 		}
-		t.Logf("tc(%v) %+v\n", tc.Name, prs)
+		if category := prSuiteErrorCategory(err); category != tc.ExpectedCategory {
+			t.Fatalf("error: category (%v) in testcase '%v' doesn't match what is expected (%v)", category, tc.Name, tc.ExpectedCategory)
+		}
+	}
+	if category := prSuiteErrorCategory(fmt.Errorf("some other error")); category != PRSuiteErrorCategory("") {
+		t.Fatalf("error: an uncategorized error must have an empty category, got '%v'", category)
 	}
 }
 
-func TestNewPRSuiteForPR(t *testing.T) {
+func TestNewPRSuiteForPRCorrectionDetection(t *testing.T) {
+	common.DataPathPrefix = "../../"
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte("# coolkube")); err != nil {
+			t.Fatalf("error: sending http response; %v", err)
+		}
+	}))
+	defer svr.Close()
 	type testCase struct {
-		Name                string
-		PullRequestQuery    *suite.PullRequestQuery
-		Labes               []github.Label
-		SupportingFiles     []*suite.PullRequestFile
-		ExpectedErrorString string
+		Name                 string
+		SupportingFiles      []*suite.PullRequestFile
+		ExpectedIsCorrection bool
 	}
-
-	common.DataPathPrefix = "../../"
-
 	for _, tc := range []testCase{
 		{
-			Name: "valid pull request entry",
-			Labes: []github.Label{
-				{
-					Name: "conformance-product-submission",
-				},
-			},
-			PullRequestQuery: &suite.PullRequestQuery{
-				Number: githubql.Int(1),
-				Repository: struct {
-					Name  githubql.String
-					Owner struct{ Login githubql.String }
-				}{
-					Name: githubql.String("cncf-ci"),
-					Owner: struct{ Login githubql.String }{
-						Login: githubql.String("cncf-ci"),
-					},
-				},
-			},
+			Name: "modify-only PR is a correction",
 			SupportingFiles: []*suite.PullRequestFile{
 				{
 					Name:     "v1.30/coolkube/README.md",
 					BaseName: "README.md",
-					BlobURL:  "README.md",
-					Contents: `# CoolKube`,
+					BlobURL:  svr.URL + "/README.md",
+					Status:   string(github.PullRequestFileModified),
 				},
 				{
 					Name:     "v1.30/coolkube/PRODUCT.yaml",
 					BaseName: "PRODUCT.yaml",
-					BlobURL:  "PRODUCT.yaml",
-					Contents: `vendor: "cool"
-name: "coolkube"
-version: "v1.30"
-type: "distribution"
-description: "it's just all-round cool and probably the best k8s, idk"
-website_url: "website_url"
-documentation_url: "docs"
-contact_email_address: "sales@coolkubernetes.com"`,
+					BlobURL:  svr.URL + "/PRODUCT.yaml",
+					Status:   string(github.PullRequestFileModified),
 				},
+			},
+			ExpectedIsCorrection: true,
+		},
+		{
+			Name: "PR adding files is a new submission",
+			SupportingFiles: []*suite.PullRequestFile{
 				{
-					Name:     "v1.30/coolkube/junit_01.xml",
-					BaseName: "junit_01.xml",
-					BlobURL:  "junit_01.xml",
-					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01xml,
+					Name:     "v1.30/coolkube/README.md",
+					BaseName: "README.md",
+					BlobURL:  svr.URL + "/README.md",
+					Status:   github.PullRequestFileAdded,
 				},
 				{
-					Name:     "v1.30/coolkube/e2e.log",
-					BaseName: "e2e.log",
-					BlobURL:  "e2e.log",
-					Contents: `cool!`,
+					Name:     "v1.30/coolkube/PRODUCT.yaml",
+					BaseName: "PRODUCT.yaml",
+					BlobURL:  svr.URL + "/PRODUCT.yaml",
+					Status:   string(github.PullRequestFileModified),
 				},
 			},
+			ExpectedIsCorrection: false,
 		},
 	} {
-		productYAML := map[string]string{}
-		var productYAMLSupportingFile string
-		for _, file := range tc.SupportingFiles {
-			if file.BaseName == "PRODUCT.yaml" {
-				productYAMLSupportingFile = file.Contents
-			}
-		}
-		if productYAMLSupportingFile != "" {
-			if err := yaml.Unmarshal([]byte(productYAMLSupportingFile), &productYAML); err != nil {
-				t.Fatalf("error: unmarshalling from PRODUCT.yaml supporting file: %v", err)
-			}
-		}
-
-		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			t.Logf("requesting path '%v'", r.URL.Path)
-			supportingFile := &suite.PullRequestFile{}
-			for _, file := range tc.SupportingFiles {
-				if r.URL.Path == "/"+file.BaseName || r.URL.Path == "/"+file.Name {
-					supportingFile = file
-				}
-			}
-			w.WriteHeader(http.StatusOK)
-			_, err := w.Write([]byte(supportingFile.Contents))
-			if err != nil {
-				t.Fatalf("error: sending http response; %v", err)
-			}
-		}))
-		defer svr.Close()
-		for _, field := range []string{"website_url", "documentation_url"} {
-			if productYAML[field] != "" {
-				productYAML[field] = svr.URL + "/" + productYAML[field]
-			}
-		}
-		productYAMLBytes, err := yaml.Marshal(productYAML)
-		if err != nil {
-			t.Fatalf("error: marshalling new product yaml: %v", err)
-		}
-		for i := range tc.SupportingFiles {
-			tc.SupportingFiles[i].BlobURL = svr.URL + "/" + tc.SupportingFiles[i].BlobURL
-			if tc.SupportingFiles[i].BaseName == "PRODUCT.yaml" {
-				tc.SupportingFiles[i].Contents = string(productYAMLBytes)
-			}
+		pullRequestQuery := &suite.PullRequestQuery{
+			Number: githubql.Int(1),
+			Repository: struct {
+				Name  githubql.String
+				Owner struct{ Login githubql.String }
+			}{
+				Name: githubql.String("cncf-ci"),
+				Owner: struct{ Login githubql.String }{
+					Login: githubql.String("cncf-ci"),
+				},
+			},
 		}
 		ghc := NewFakeGitHubClient([]*prContext{
 			{
-				PullRequestQuery: tc.PullRequestQuery,
+				PullRequestQuery: pullRequestQuery,
 				SupportingFiles:  tc.SupportingFiles,
 			},
 		})
-		prSuite, err := NewPRSuiteForPR(log, ghc, tc.PullRequestQuery)
-		if err != nil && strings.Contains(err.Error(), tc.ExpectedErrorString) {
-			t.Fatalf("unexpected error in testcase '%v': %v", tc.Name, err)
+		prSuite, err := NewPRSuiteForPR(log, ghc, pullRequestQuery)
+		if err != nil {
+			t.Fatalf("error: in testcase '%v'; %v", tc.Name, err)
 		}
-		t.Logf("prSuite: %+v\n", prSuite)
-	}
-}
-
-func TestGetGodogPaths(t *testing.T) {
-	paths := GetGodogPaths()
-	found := false
-	for _, p := range paths {
-		if p == "../../kodata/features/" || p == "./kodata/features/" {
-			found = true
-			break
+		if prSuite.PR.IsCorrection != tc.ExpectedIsCorrection {
+			t.Fatalf("error: IsCorrection (%v) in testcase '%v' doesn't match what is expected (%v)", prSuite.PR.IsCorrection, tc.Name, tc.ExpectedIsCorrection)
 		}
 	}
-	if !found {
-		t.Fatalf("error: unable to find features folder for godog paths")
-	}
 }
 
-func Test_labelIsManaged(t *testing.T) {
+func TestNewPRSuiteForPRWithProductLogo(t *testing.T) {
+	common.DataPathPrefix = "../../"
 	type testCase struct {
-		Label          string
-		ExpectedResult bool
+		Name                string
+		LogoContentType     string
+		LogoContent         string
+		ExpectedContentType string
+		ExpectedContent     string
 	}
 
 	for _, tc := range []testCase{
 		{
-			Label:          "conformance-product-submission",
-			ExpectedResult: true,
+			Name:                "valid svg logo",
+			LogoContentType:     "image/svg+xml",
+			LogoContent:         `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 100 100"></svg>`,
+			ExpectedContentType: "image/svg+xml",
+			ExpectedContent:     `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 100 100"></svg>`,
 		},
 		{
-			Label:          "not-verifiable",
-			ExpectedResult: true,
+			Name:                "png masquerading via the svg extension",
+			LogoContentType:     "image/png",
+			LogoContent:         "\x89PNG\r\n\x1a\n",
+			ExpectedContentType: "image/png",
+			ExpectedContent:     "\x89PNG\r\n\x1a\n",
 		},
-		{
-			Label:          "release-documents-checked",
-			ExpectedResult: true,
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/logo.svg":
+					w.Header().Set("Content-Type", tc.LogoContentType)
+					w.WriteHeader(http.StatusOK)
+					if _, err := w.Write([]byte(tc.LogoContent)); err != nil {
+						t.Errorf("error: sending http response; %v", err)
+					}
+				case "/PRODUCT.yaml":
+					w.WriteHeader(http.StatusOK)
+					productYAML := "vendor: \"cool\"\nname: \"coolkube\"\nproduct_logo_url: \"" + svrURLForLogo(r) + "/logo.svg\"\n"
+					if _, err := w.Write([]byte(productYAML)); err != nil {
+						t.Errorf("error: sending http response; %v", err)
+					}
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer svr.Close()
+			pullRequestQuery := &suite.PullRequestQuery{
+				Number: githubql.Int(1),
+				Repository: struct {
+					Name  githubql.String
+					Owner struct{ Login githubql.String }
+				}{
+					Name: githubql.String("cncf-ci"),
+					Owner: struct{ Login githubql.String }{
+						Login: githubql.String("cncf-ci"),
+					},
+				},
+			}
+			ghc := NewFakeGitHubClient([]*prContext{
+				{
+					PullRequestQuery: pullRequestQuery,
+					SupportingFiles: []*suite.PullRequestFile{
+						{
+							Name:     "v1.30/coolkube/PRODUCT.yaml",
+							BaseName: "PRODUCT.yaml",
+							BlobURL:  svr.URL + "/PRODUCT.yaml",
+						},
+					},
+				},
+			})
+			prSuite, err := NewPRSuiteForPR(log, ghc, pullRequestQuery)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if contentType := prSuite.PR.ProductYAMLURLDataTypes["product_logo_url"]; !strings.Contains(contentType, tc.ExpectedContentType) {
+				t.Fatalf("error: logo content type (%v) doesn't match expected content type (%v)", contentType, tc.ExpectedContentType)
+			}
+			if prSuite.PR.ProductLogoContent != tc.ExpectedContent {
+				t.Fatalf("error: logo content (%v) doesn't match expected content (%v)", prSuite.PR.ProductLogoContent, tc.ExpectedContent)
+			}
+		})
+	}
+}
+
+// svgURLForLogo returns the base URL of the test server handling the request,
+// so PRODUCT.yaml can reference the logo served by the same server
+func svrURLForLogo(r *http.Request) string {
+	return "http://" + r.Host
+}
+
+func TestNewPRSuiteForPRCollectsAllFiles(t *testing.T) {
+	common.DataPathPrefix = "../../"
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte("content of " + r.URL.Path)); err != nil {
+			t.Fatalf("error: sending http response; %v", err)
+		}
+	}))
+	defer svr.Close()
+	supportingFiles := []*suite.PullRequestFile{}
+	for i := 0; i < 25; i++ {
+		name := fmt.Sprintf("v1.30/coolkube/file-%02d.txt", i)
+		supportingFiles = append(supportingFiles, &suite.PullRequestFile{
+			Name:     name,
+			BaseName: path.Base(name),
+			BlobURL:  svr.URL + "/" + name,
+		})
+	}
+	pullRequestQuery := &suite.PullRequestQuery{
+		Number: githubql.Int(1),
+		Repository: struct {
+			Name  githubql.String
+			Owner struct{ Login githubql.String }
+		}{
+			Name: githubql.String("cncf-ci"),
+			Owner: struct{ Login githubql.String }{
+				Login: githubql.String("cncf-ci"),
+			},
 		},
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
 		{
-			Label:          "required-tests-missing",
-			ExpectedResult: true,
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles:  supportingFiles,
 		},
-		{
-			Label:          "evidence-missing",
-			ExpectedResult: true,
+	})
+	prSuite, err := NewPRSuiteForPR(log, ghc, pullRequestQuery)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if len(prSuite.PR.SupportingFiles) != len(supportingFiles) {
+		t.Fatalf("error: expected %v supporting files, found %v", len(supportingFiles), len(prSuite.PR.SupportingFiles))
+	}
+}
+
+func TestNewPRSuiteForPRSlowProductURL(t *testing.T) {
+	originalTimeout := productYAMLURLCheckTimeout
+	defer SetProductYAMLURLCheckTimeout(originalTimeout)
+	SetProductYAMLURLCheckTimeout(50 * time.Millisecond)
+
+	common.DataPathPrefix = "../../"
+	productYAMLContents := ""
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(500 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(productYAMLContents)); err != nil {
+			t.Logf("error: sending http response; %v", err)
+		}
+	}))
+	defer svr.Close()
+	productYAMLContents = fmt.Sprintf(`vendor: "cool"
+name: "coolkube"
+version: "v1.30"
+website_url: "%v/slow"
+documentation_url: "%v/docs"`, svr.URL, svr.URL)
+	pullRequestQuery := &suite.PullRequestQuery{
+		Number: githubql.Int(1),
+		Repository: struct {
+			Name  githubql.String
+			Owner struct{ Login githubql.String }
+		}{
+			Name: githubql.String("cncf-ci"),
+			Owner: struct{ Login githubql.String }{
+				Login: githubql.String("cncf-ci"),
+			},
 		},
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
 		{
-			Label:          "unable-to-process",
-			ExpectedResult: true,
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles: []*suite.PullRequestFile{
+				{
+					Name:     "v1.30/coolkube/PRODUCT.yaml",
+					BaseName: "PRODUCT.yaml",
+					BlobURL:  svr.URL + "/PRODUCT.yaml",
+				},
+			},
 		},
-		{
-			Label:          "some-kinda-label",
-			ExpectedResult: false,
+	})
+	prSuite, err := NewPRSuiteForPR(log, ghc, pullRequestQuery)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if contentType := prSuite.PR.ProductYAMLURLDataTypes["website_url"]; contentType != "" {
+		t.Fatalf("error: expected an empty content-type for the timed out URL, got '%v'", contentType)
+	}
+	if contentType := prSuite.PR.ProductYAMLURLDataTypes["documentation_url"]; contentType == "" {
+		t.Fatalf("error: expected a content-type for the responsive URL")
+	}
+}
+
+func TestNewPRSuiteForPRHeadRejected(t *testing.T) {
+	common.DataPathPrefix = "../../"
+	productYAMLContents := ""
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/head-rejected" {
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.Header().Set("Content-Type", "text/html")
+		}
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(productYAMLContents)); err != nil {
+			t.Logf("error: sending http response; %v", err)
+		}
+	}))
+	defer svr.Close()
+	productYAMLContents = fmt.Sprintf(`vendor: "cool"
+name: "coolkube"
+version: "v1.30"
+website_url: "%v/head-rejected"`, svr.URL)
+	pullRequestQuery := &suite.PullRequestQuery{
+		Number: githubql.Int(1),
+		Repository: struct {
+			Name  githubql.String
+			Owner struct{ Login githubql.String }
+		}{
+			Name: githubql.String("cncf-ci"),
+			Owner: struct{ Login githubql.String }{
+				Login: githubql.String("cncf-ci"),
+			},
 		},
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
 		{
-			Label:          "non-managed",
-			ExpectedResult: false,
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles: []*suite.PullRequestFile{
+				{
+					Name:     "v1.30/coolkube/PRODUCT.yaml",
+					BaseName: "PRODUCT.yaml",
+					BlobURL:  svr.URL + "/PRODUCT.yaml",
+				},
+			},
 		},
-	} {
-		if result := labelIsManaged(tc.Label); result != tc.ExpectedResult {
-			t.Fatalf("error: label (%v) not expected to be managed", tc.Label)
+	})
+	prSuite, err := NewPRSuiteForPR(log, ghc, pullRequestQuery)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if contentType := prSuite.PR.ProductYAMLURLDataTypes["website_url"]; !strings.Contains(contentType, "text/html") {
+		t.Fatalf("error: expected the content-type to be resolved via GET fallback, got '%v'", contentType)
+	}
+}
+
+func TestNewPRSuiteForPRURLStatusCodes(t *testing.T) {
+	common.DataPathPrefix = "../../"
+	productYAMLContents := ""
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/not-found":
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case "/moved":
+			http.Redirect(w, r, "/landed", http.StatusMovedPermanently)
+			return
+		case "/landed":
+			w.Header().Set("Content-Type", "text/html")
 		}
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(productYAMLContents)); err != nil {
+			t.Logf("error: sending http response; %v", err)
+		}
+	}))
+	defer svr.Close()
+	productYAMLContents = fmt.Sprintf(`vendor: "cool"
+name: "coolkube"
+version: "v1.30"
+website_url: "%v/not-found"
+documentation_url: "%v/moved"`, svr.URL, svr.URL)
+	pullRequestQuery := &suite.PullRequestQuery{
+		Number: githubql.Int(1),
+		Repository: struct {
+			Name  githubql.String
+			Owner struct{ Login githubql.String }
+		}{
+			Name: githubql.String("cncf-ci"),
+			Owner: struct{ Login githubql.String }{
+				Login: githubql.String("cncf-ci"),
+			},
+		},
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles: []*suite.PullRequestFile{
+				{
+					Name:     "v1.30/coolkube/PRODUCT.yaml",
+					BaseName: "PRODUCT.yaml",
+					BlobURL:  svr.URL + "/PRODUCT.yaml",
+				},
+			},
+		},
+	})
+	prSuite, err := NewPRSuiteForPR(log, ghc, pullRequestQuery)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if statusCode := prSuite.PR.ProductYAMLURLStatusCodes["website_url"]; statusCode != http.StatusNotFound {
+		t.Fatalf("error: expected website_url to record status 404, got '%v'", statusCode)
+	}
+	if statusCode := prSuite.PR.ProductYAMLURLStatusCodes["documentation_url"]; statusCode != http.StatusOK {
+		t.Fatalf("error: expected documentation_url to record the status after following redirects, got '%v'", statusCode)
 	}
 }
 
-func Test_labelIsVersionLabel(t *testing.T) {
+func Test_productYAMLURLBlockedReason(t *testing.T) {
+	defaultAllowedHosts := productYAMLURLAllowedHosts
+	defaultDeniedHosts := productYAMLURLDeniedHosts
+	defer func() {
+		productYAMLURLAllowedHosts = defaultAllowedHosts
+		productYAMLURLDeniedHosts = defaultDeniedHosts
+	}()
+	SetProductYAMLURLHostPolicy(nil, nil)
+
 	type testCase struct {
-		Label          string
-		Version        string
-		ExpectedResult bool
+		name    string
+		host    string
+		blocked bool
 	}
 
 	for _, tc := range []testCase{
 		{
-			Label:          "release-v1.27",
-			Version:        "v1.27",
-			ExpectedResult: true,
+			name:    "cloud metadata endpoint",
+			host:    "169.254.169.254",
+			blocked: true,
 		},
 		{
-			Label:          "release-v1.26",
-			Version:        "v1.26",
-			ExpectedResult: true,
+			name:    "rfc1918 address",
+			host:    "10.0.0.8",
+			blocked: true,
 		},
 		{
-			Label:          "no-failed-tests-v1.27",
-			Version:        "v1.27",
-			ExpectedResult: true,
+			name:    "loopback address",
+			host:    "127.0.0.1",
+			blocked: true,
 		},
 		{
-			Label:          "no-failed-tests-v1.26",
-			Version:        "v1.26",
-			ExpectedResult: true,
+			name:    "ipv6 loopback address",
+			host:    "::1",
+			blocked: true,
 		},
 		{
-			Label:          "tests-verified-v1.27",
-			Version:        "v1.27",
-			ExpectedResult: true,
+			name:    "unspecified address",
+			host:    "0.0.0.0",
+			blocked: true,
 		},
 		{
-			Label:          "am-i-a-label-v1.27",
-			Version:        "v1.27",
-			ExpectedResult: false,
+			name: "public address",
+			host: "192.0.2.10",
 		},
 		{
-			Label:          "thing",
-			Version:        "v1.27",
-			ExpectedResult: false,
+			name:    "empty host",
+			host:    "",
+			blocked: true,
 		},
 	} {
-		if result := labelIsVersionLabel(tc.Label, tc.Version); result != tc.ExpectedResult {
-			t.Fatalf("error: version label (%v) does not match expected result (%v)", tc.Label, tc.ExpectedResult)
-		}
+		t.Run(tc.name, func(t *testing.T) {
+			reason := productYAMLURLBlockedReason(tc.host)
+			if blocked := reason != ""; blocked != tc.blocked {
+				t.Fatalf("error: productYAMLURLBlockedReason(%v) = '%v', want blocked %v", tc.host, reason, tc.blocked)
+			}
+		})
 	}
-}
 
-func Test_labelIsFileLabel(t *testing.T) {
-	type testCase struct {
-		Name           string
-		Label          string
-		MissingFiles   []string
-		ExpectedResult bool
+	SetProductYAMLURLHostPolicy(nil, []string{"evil.example.com"})
+	if reason := productYAMLURLBlockedReason("evil.example.com"); !strings.Contains(reason, "denylist") {
+		t.Fatalf("error: expected a denylisted host to be refused, got '%v'", reason)
+	}
+	SetProductYAMLURLHostPolicy([]string{"example.com"}, nil)
+	if reason := productYAMLURLBlockedReason("example.com"); reason != "" {
+		t.Fatalf("error: expected an allowlisted host to be accepted, got '%v'", reason)
 	}
+	if reason := productYAMLURLBlockedReason("other.example.com"); !strings.Contains(reason, "allowlist") {
+		t.Fatalf("error: expected a host off the allowlist to be refused, got '%v'", reason)
+	}
+}
 
-	for _, tc := range []testCase{
-		{
-			Label:          "missing-file-README.md",
-			MissingFiles:   []string{"README.md"},
-			ExpectedResult: true,
-		},
-		{
-			Label:          "missing-file-e2e.log",
-			MissingFiles:   []string{"e2e.log"},
-			ExpectedResult: true,
-		},
-		{
-			Label:          "missing-file-junit_01.xml",
-			MissingFiles:   []string{"junit_01.xml"},
-			ExpectedResult: true,
-		},
-		{
-			Label:          "missing-file-PRODUCT.yaml",
-			MissingFiles:   []string{"PRODUCT.yaml"},
-			ExpectedResult: true,
-		},
-		{
-			Label:          "missing-file-README.md",
-			ExpectedResult: true,
+func Test_checkURLRedirectHostPolicy(t *testing.T) {
+	defaultAllowedHosts := productYAMLURLAllowedHosts
+	defaultDeniedHosts := productYAMLURLDeniedHosts
+	defer func() {
+		productYAMLURLAllowedHosts = defaultAllowedHosts
+		productYAMLURLDeniedHosts = defaultDeniedHosts
+	}()
+	SetProductYAMLURLHostPolicy(nil, nil)
+
+	// a public URL that redirects to a non-public address must be refused at
+	// the hop, not just at the initially submitted host
+	req, err := http.NewRequest(http.MethodHead, "http://169.254.169.254/latest/meta-data/", nil)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	via, err := http.NewRequest(http.MethodHead, "http://public.example.com/", nil)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if err := checkURLRedirect(req, []*http.Request{via}); err == nil {
+		t.Fatalf("error: expected a redirect to a non-public address to be refused")
+	}
+
+	SetProductYAMLURLHostPolicy([]string{"example.com"}, nil)
+	allowed, err := http.NewRequest(http.MethodHead, "http://example.com/landing", nil)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if err := checkURLRedirect(allowed, []*http.Request{via}); err != nil {
+		t.Fatalf("error: expected a redirect to an allowlisted host to be followed; %v", err)
+	}
+}
+
+func TestNewPRSuiteForPRBlockedInternalURL(t *testing.T) {
+	common.DataPathPrefix = "../../"
+	productYAMLContents := ""
+	requestedPaths := []string{}
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPaths = append(requestedPaths, r.URL.Path)
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(productYAMLContents)); err != nil {
+			t.Logf("error: sending http response; %v", err)
+		}
+	}))
+	defer svr.Close()
+	productYAMLContents = fmt.Sprintf(`vendor: "cool"
+name: "coolkube"
+version: "v1.30"
+website_url: "http://169.254.169.254/latest/meta-data"
+documentation_url: "%v/docs"`, svr.URL)
+	pullRequestQuery := &suite.PullRequestQuery{
+		Number: githubql.Int(1),
+		Repository: struct {
+			Name  githubql.String
+			Owner struct{ Login githubql.String }
+		}{
+			Name: githubql.String("cncf-ci"),
+			Owner: struct{ Login githubql.String }{
+				Login: githubql.String("cncf-ci"),
+			},
 		},
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
 		{
-			Label:          "missing-file-e2e.log",
-			ExpectedResult: true,
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles: []*suite.PullRequestFile{
+				{
+					Name:     "v1.30/coolkube/PRODUCT.yaml",
+					BaseName: "PRODUCT.yaml",
+					BlobURL:  svr.URL + "/PRODUCT.yaml",
+				},
+			},
 		},
+	})
+	prSuite, err := NewPRSuiteForPR(log, ghc, pullRequestQuery)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if contentType := prSuite.PR.ProductYAMLURLDataTypes["website_url"]; contentType != "" {
+		t.Fatalf("error: expected the internal website_url to be left unresolved, got '%v'", contentType)
+	}
+	if contentType := prSuite.PR.ProductYAMLURLDataTypes["documentation_url"]; contentType == "" {
+		t.Fatalf("error: expected the allowed documentation_url to be resolved")
+	}
+	for _, requestedPath := range requestedPaths {
+		if strings.Contains(requestedPath, "meta-data") {
+			t.Fatalf("error: a request was made for the blocked URL")
+		}
+	}
+}
+
+func TestNewPRSuiteForPREmptyProductYAML(t *testing.T) {
+	common.DataPathPrefix = "../../"
+	type testCase struct {
+		name          string
+		contents      string
+		expectedEmpty bool
+	}
+	for _, tc := range []testCase{
 		{
-			Label:          "missing-file-junit_01.xml",
-			ExpectedResult: true,
+			name:          "empty file",
+			contents:      "",
+			expectedEmpty: true,
 		},
 		{
-			Label:          "missing-file-PRODUCT.yaml",
-			ExpectedResult: true,
+			name:          "whitespace only",
+			contents:      "   \n\t\n",
+			expectedEmpty: true,
 		},
 		{
-			Label:          "hi-im-a-label",
-			ExpectedResult: false,
+			name:          "null yaml document",
+			contents:      "null",
+			expectedEmpty: true,
 		},
 		{
-			Label:          "missing-fil-PRODUCT.yaml",
-			ExpectedResult: false,
+			name:     "populated file",
+			contents: "vendor: \"cool\"\nname: \"coolkube\"",
 		},
 	} {
-		if result := labelIsFileLabel(tc.Label, tc.MissingFiles); result != tc.ExpectedResult {
-			t.Fatalf("error: file label is not expected for %v (%v) with result (%v)", tc.Label, tc.ExpectedResult, result)
-		}
+		t.Run(tc.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				if _, err := w.Write([]byte(tc.contents)); err != nil {
+					t.Logf("error: sending http response; %v", err)
+				}
+			}))
+			defer svr.Close()
+			pullRequestQuery := &suite.PullRequestQuery{
+				Number: githubql.Int(1),
+				Repository: struct {
+					Name  githubql.String
+					Owner struct{ Login githubql.String }
+				}{
+					Name: githubql.String("cncf-ci"),
+					Owner: struct{ Login githubql.String }{
+						Login: githubql.String("cncf-ci"),
+					},
+				},
+			}
+			ghc := NewFakeGitHubClient([]*prContext{
+				{
+					PullRequestQuery: pullRequestQuery,
+					SupportingFiles: []*suite.PullRequestFile{
+						{
+							Name:     "v1.30/coolkube/PRODUCT.yaml",
+							BaseName: "PRODUCT.yaml",
+							BlobURL:  svr.URL + "/PRODUCT.yaml",
+						},
+					},
+				},
+			})
+			prSuite, err := NewPRSuiteForPR(log, ghc, pullRequestQuery)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if prSuite.ProductYAMLIsEmpty != tc.expectedEmpty {
+				t.Fatalf("error: ProductYAMLIsEmpty = %v, want %v", prSuite.ProductYAMLIsEmpty, tc.expectedEmpty)
+			}
+		})
 	}
 }
 
-func Test_removeSliceOfStringsFromStringSlice(t *testing.T) {
-	type testCase struct {
-		Input          []string
-		Remove         []string
-		ExpectedOutput []string
+func TestNewPRSuiteForPRNoFilesChanged(t *testing.T) {
+	common.DataPathPrefix = "../../"
+	pullRequestQuery := &suite.PullRequestQuery{
+		Number: githubql.Int(1),
+		Repository: struct {
+			Name  githubql.String
+			Owner struct{ Login githubql.String }
+		}{
+			Name: githubql.String("cncf-ci"),
+			Owner: struct{ Login githubql.String }{
+				Login: githubql.String("cncf-ci"),
+			},
+		},
 	}
-
-	for _, tc := range []testCase{
+	ghc := NewFakeGitHubClient([]*prContext{
 		{
-			Input:          []string{"a", "b", "c", "d", "e"},
-			Remove:         []string{"b", "e"},
-			ExpectedOutput: []string{"a", "c", "d"},
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles:  []*suite.PullRequestFile{},
 		},
-	} {
-		result := removeSliceOfStringsFromStringSlice(tc.Input, tc.Remove)
-		if len(result) != len(tc.ExpectedOutput) {
-			t.Fatalf("error: slice (%v) length is mismatching (%v)", len(result), len(tc.ExpectedOutput))
-		}
-		itemsMatching := []string{}
-		for _, i := range tc.ExpectedOutput {
-			for _, r := range result {
-				if i == r {
-					itemsMatching = append(itemsMatching, r)
-				}
-			}
-		}
-		if len(itemsMatching) != len(tc.ExpectedOutput) {
-			t.Fatalf("error: items matching count (%v) doesn't equal the expected output count (%v)", len(itemsMatching), len(tc.ExpectedOutput))
-		}
+	})
+	prSuite, err := NewPRSuiteForPR(log, ghc, pullRequestQuery)
+	if err != nil {
+		t.Fatalf("error: %v", err)
 	}
-}
-
-func Test_handle(t *testing.T) {
-	if err := os.Setenv("KO_DATA_PATH", "./../../kodata"); err != nil {
-		log.Fatalf("failed to set env: %v", err)
+	if !prSuite.HasNoFilesChanged {
+		t.Fatalf("error: HasNoFilesChanged = false, want true")
 	}
-	type testCase struct {
-		Name                    string
-		KubernetesVersion       *string
-		KubernetesVersionLatest *string
-		PullRequestQuery        *suite.PullRequestQuery
-		SupportingFiles         []*suite.PullRequestFile
-		Labels                  []string
-		ExpectedLabels          []string
-		ExpectedComment         string
-		ExpectedStatus          string
-		ExpectedError           string
+	if len(prSuite.PR.SupportingFiles) != 0 {
+		t.Fatalf("error: expected no supporting files, found %v", len(prSuite.PR.SupportingFiles))
 	}
+}
 
-	for _, tc := range []testCase{
+func TestNewPRSuiteForPRFetchesLargeFileViaBlobAPI(t *testing.T) {
+	common.DataPathPrefix = "../../"
+	defaultGithubAPIBaseURL := githubAPIBaseURL
+	defer func() {
+		githubAPIBaseURL = defaultGithubAPIBaseURL
+	}()
+	fullContent := strings.Repeat("some very long line of e2e output\n", 40000) + "END OF LOG\n"
+	truncatedContent := fullContent[:largeFileSizeThreshold]
+	blobRequested := false
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/git/blobs/"):
+			blobRequested = true
+			w.Header().Set("Content-Type", "application/json")
+			body, err := json.Marshal(blobContent{
+				Content:  base64.StdEncoding.EncodeToString([]byte(fullContent)),
+				Encoding: "base64",
+				Size:     len(fullContent),
+			})
+			if err != nil {
+				t.Logf("error: marshalling blob response; %v", err)
+			}
+			if _, err := w.Write(body); err != nil {
+				t.Logf("error: sending http response; %v", err)
+			}
+		case r.URL.Path == "/e2e.log":
+			if _, err := w.Write([]byte(truncatedContent)); err != nil {
+				t.Logf("error: sending http response; %v", err)
+			}
+		default:
+			if _, err := w.Write([]byte("vendor: \"cool\"\nname: \"coolkube\"")); err != nil {
+				t.Logf("error: sending http response; %v", err)
+			}
+		}
+	}))
+	defer svr.Close()
+	githubAPIBaseURL = svr.URL
+
+	pullRequestQuery := &suite.PullRequestQuery{
+		Number: githubql.Int(1),
+		Repository: struct {
+			Name  githubql.String
+			Owner struct{ Login githubql.String }
+		}{
+			Name: githubql.String("cncf-ci"),
+			Owner: struct{ Login githubql.String }{
+				Login: githubql.String("cncf-ci"),
+			},
+		},
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
 		{
-			Name:                    "valid submission",
-			Labels:                  []string{"conformance-product-submission"},
-			KubernetesVersion:       common.Pointer("v1.30"),
-			KubernetesVersionLatest: common.Pointer("v1.30"),
-			ExpectedComment:         "have passed for the submission",
-			ExpectedStatus:          "success",
-			ExpectedLabels:          []string{"conformance-product-submission", "tests-verified-v1.30", "no-failed-tests-v1.30", "release-v1.30", "release-documents-checked"},
+			PullRequestQuery: pullRequestQuery,
 			SupportingFiles: []*suite.PullRequestFile{
 				{
-					Name:     "v1.30/coolkube/README.md",
-					BaseName: "README.md",
-					Contents: `# coolkube
-> the coolest Kubernetes distribution
+					Name:     "v1.30/coolkube/PRODUCT.yaml",
+					BaseName: "PRODUCT.yaml",
+					BlobURL:  svr.URL + "/PRODUCT.yaml",
+				},
+				{
+					Name:     "v1.30/coolkube/e2e.log",
+					BaseName: "e2e.log",
+					BlobURL:  svr.URL + "/e2e.log",
+					SHA:      "0a1b2c3d4e5f",
+				},
+			},
+		},
+	})
+	prSuite, err := NewPRSuiteForPR(log, ghc, pullRequestQuery)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if !blobRequested {
+		t.Fatalf("error: expected the oversized file to be re-fetched via the blobs API")
+	}
+	file := prSuite.GetFileByFileName("e2e.log")
+	if file == nil {
+		t.Fatalf("error: expected to find e2e.log in the suite")
+	}
+	if file.Contents != fullContent {
+		t.Fatalf("error: expected the complete e2e.log content (%v bytes), found %v bytes", len(fullContent), len(file.Contents))
+	}
+}
 
-## Generating conformance results
+func TestNewPRSuiteForPRPrivateRepoFetchesViaContentsAPI(t *testing.T) {
+	common.DataPathPrefix = "../../"
+	rawRequested := false
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawRequested = true
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer svr.Close()
 
-1. create a coolkube cluster
-2. sonobuoy run --wait && sonobuoy results "$(sonobuoy retrieve)" && sonobuoy delete --wait`,
-					BlobURL: "README.md",
-				},
+	pullRequestQuery := &suite.PullRequestQuery{
+		Number: githubql.Int(1),
+		Repository: struct {
+			Name  githubql.String
+			Owner struct{ Login githubql.String }
+		}{
+			Name: githubql.String("cncf-ci"),
+			Owner: struct{ Login githubql.String }{
+				Login: githubql.String("cncf-ci"),
+			},
+		},
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+			RepoIsPrivate:    true,
+			SupportingFiles: []*suite.PullRequestFile{
 				{
 					Name:     "v1.30/coolkube/PRODUCT.yaml",
 					BaseName: "PRODUCT.yaml",
-					Contents: `vendor: "cool"
-name: "coolkube"
-version: "v1.30"
-type: "distribution"
-description: "it's just all-round cool and probably the best k8s, idk"
-website_url: "website_url"
-documentation_url: "docs"
-contact_email_address: "sales@coolkubernetes.com"`,
-					BlobURL: "PRODUCT.yaml",
+					Contents: "vendor: \"cool\"\nname: \"coolkube\"",
+					BlobURL:  svr.URL + "/PRODUCT.yaml",
 				},
 				{
 					Name:     "v1.30/coolkube/e2e.log",
 					BaseName: "e2e.log",
-					Contents: "12345",
-					BlobURL:  "e2e.log",
-				},
-				{
-					Name:     "v1.30/coolkube/junit_01.xml",
-					BaseName: "junit_01.xml",
-					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01xml,
-					BlobURL:  "junit_01.xml",
+					Contents: "Ran 2 of 2 Specs in 10.0 seconds",
+					BlobURL:  svr.URL + "/e2e.log",
 				},
 			},
-			PullRequestQuery: &suite.PullRequestQuery{
-				Title:  githubql.String("Conformance results for v1.30/coolkube"),
-				Number: githubql.Int(0),
-				Commits: struct {
-					Nodes []struct {
-						Commit struct {
-							Oid    githubql.String
-							Status struct {
-								Contexts []struct {
-									Context githubql.String
-									State   githubql.String
-								}
-							}
-						}
-					}
-				}{
-					Nodes: []struct {
-						Commit struct {
-							Oid    githubql.String
-							Status struct {
-								Contexts []struct {
-									Context githubql.String
-									State   githubql.String
-								}
-							}
-						}
-					}{
-						{
-							Commit: struct {
-								Oid    githubql.String
-								Status struct {
-									Contexts []struct {
-										Context githubql.String
-										State   githubql.String
-									}
-								}
-							}{
-								Oid: githubql.String(""),
-								Status: struct {
-									Contexts []struct {
-										Context githubql.String
-										State   githubql.String
-									}
-								}{
-									Contexts: []struct {
-										Context githubql.String
-										State   githubql.String
-									}{
-										{
-											Context: githubql.String(""),
-											State:   githubql.String(""),
-										},
-									},
-								},
-							},
-						},
-					},
-				},
+		},
+	})
+	prSuite, err := NewPRSuiteForPR(log, ghc, pullRequestQuery)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if rawRequested {
+		t.Fatalf("error: expected no raw URL fetches for a private repo")
+	}
+	file := prSuite.GetFileByFileName("e2e.log")
+	if file == nil {
+		t.Fatalf("error: expected to find e2e.log in the suite")
+	}
+	if file.Contents != "Ran 2 of 2 Specs in 10.0 seconds" {
+		t.Fatalf("error: unexpected e2e.log content; %v", file.Contents)
+	}
+}
+
+func TestNewPRSuiteForPROversizedFile(t *testing.T) {
+	common.DataPathPrefix = "../../"
+	defaultMaxFileSizeBytes := maxFileSizeBytes
+	defer func() {
+		maxFileSizeBytes = defaultMaxFileSizeBytes
+	}()
+	maxFileSizeBytes = 1024
+	oversizedContent := strings.Repeat("some very long line of e2e output\n", 100)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/e2e.log":
+			if _, err := w.Write([]byte(oversizedContent)); err != nil {
+				t.Logf("error: sending http response; %v", err)
+			}
+		default:
+			if _, err := w.Write([]byte("vendor: \"cool\"\nname: \"coolkube\"")); err != nil {
+				t.Logf("error: sending http response; %v", err)
+			}
+		}
+	}))
+	defer svr.Close()
+
+	pullRequestQuery := &suite.PullRequestQuery{
+		Number: githubql.Int(1),
+		Repository: struct {
+			Name  githubql.String
+			Owner struct{ Login githubql.String }
+		}{
+			Name: githubql.String("cncf-ci"),
+			Owner: struct{ Login githubql.String }{
+				Login: githubql.String("cncf-ci"),
 			},
 		},
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
 		{
-			Name:                    "valid submission for unreleased version",
-			Labels:                  []string{"conformance-product-submission"},
-			KubernetesVersion:       common.Pointer("v1.57"),
-			KubernetesVersionLatest: common.Pointer("v1.57"),
+			PullRequestQuery: pullRequestQuery,
 			SupportingFiles: []*suite.PullRequestFile{
 				{
-					Name:     "v1.57/coolkube/README.md",
-					BaseName: "README.md",
-					Contents: `# coolkube
-> the coolest Kubernetes distribution
-
-## Generating conformance results
-
-1. create a coolkube cluster
-2. sonobuoy run --wait && sonobuoy results "$(sonobuoy retrieve)" && sonobuoy delete --wait`,
-					BlobURL: "README.md",
-				},
-				{
-					Name:     "v1.57/coolkube/PRODUCT.yaml",
+					Name:     "v1.30/coolkube/PRODUCT.yaml",
 					BaseName: "PRODUCT.yaml",
-					Contents: `vendor: "cool"
-name: "coolkube"
-version: "v1.57"
-type: "distribution"
-description: "it's just all-round cool and probably the best k8s, idk"
-website_url: "website_url"
-documentation_url: "docs"
-contact_email_address: "sales@coolkubernetes.com"`,
-					BlobURL: "PRODUCT.yaml",
+					BlobURL:  svr.URL + "/PRODUCT.yaml",
 				},
 				{
-					Name:     "v1.57/coolkube/e2e.log",
+					Name:     "v1.30/coolkube/e2e.log",
 					BaseName: "e2e.log",
-					Contents: "",
-					BlobURL:  "e2e.log",
+					BlobURL:  svr.URL + "/e2e.log",
 				},
+			},
+		},
+	})
+	prSuite, err := NewPRSuiteForPR(log, ghc, pullRequestQuery)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	file := prSuite.GetFileByFileName("e2e.log")
+	if file == nil {
+		t.Fatalf("error: expected to find e2e.log in the suite")
+	}
+	if int64(len(file.Contents)) > maxFileSizeBytes {
+		t.Fatalf("error: expected e2e.log contents to be capped at %v bytes, found %v bytes", maxFileSizeBytes, len(file.Contents))
+	}
+	foundLabel := false
+	for _, l := range prSuite.Labels {
+		if l == "oversized-file-e2e.log" {
+			foundLabel = true
+		}
+	}
+	if !foundLabel {
+		t.Fatalf("error: expected the label 'oversized-file-e2e.log' to be set, found %v", prSuite.Labels)
+	}
+}
+
+// stubHTTPClient answers requests from canned responses keyed by URL path,
+// without any network access
+type stubHTTPClient struct {
+	bodies       map[string]string
+	contentTypes map[string]string
+	requests     []string
+}
+
+func (s *stubHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	s.requests = append(s.requests, req.URL.String())
+	header := http.Header{}
+	if contentType, ok := s.contentTypes[req.URL.Path]; ok {
+		header.Set("Content-Type", contentType)
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(s.bodies[req.URL.Path])),
+		Request:    req,
+	}, nil
+}
+
+func TestNewPRSuiteForPRWithStubHTTPClient(t *testing.T) {
+	common.DataPathPrefix = "../../"
+	defaultFetchClient := fetchClient
+	defaultProductYAMLURLClient := productYAMLURLClient
+	defaultAllowedHosts := productYAMLURLAllowedHosts
+	defer func() {
+		fetchClient = defaultFetchClient
+		productYAMLURLClient = defaultProductYAMLURLClient
+		productYAMLURLAllowedHosts = defaultAllowedHosts
+	}()
+	stub := &stubHTTPClient{
+		bodies: map[string]string{
+			"/PRODUCT.yaml": "vendor: \"cool\"\nname: \"coolkube\"\nwebsite_url: \"https://coolkubernetes.com/website\"",
+		},
+		contentTypes: map[string]string{
+			"/website": "text/html",
+		},
+	}
+	SetHTTPClient(stub)
+	SetProductYAMLURLHostPolicy([]string{"coolkubernetes.com"}, nil)
+
+	pullRequestQuery := &suite.PullRequestQuery{
+		Number: githubql.Int(1),
+		Repository: struct {
+			Name  githubql.String
+			Owner struct{ Login githubql.String }
+		}{
+			Name: githubql.String("cncf-ci"),
+			Owner: struct{ Login githubql.String }{
+				Login: githubql.String("cncf-ci"),
+			},
+		},
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles: []*suite.PullRequestFile{
 				{
-					Name:     "v1.57/coolkube/junit_01.xml",
-					BaseName: "junit_01.xml",
-					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01xml,
-					BlobURL:  "junit_01.xml",
+					Name:     "v1.30/coolkube/PRODUCT.yaml",
+					BaseName: "PRODUCT.yaml",
+					BlobURL:  "https://example.com/PRODUCT.yaml",
 				},
 			},
-			ExpectedComment: "The release version v1.57 is unable to be processed at this time; Please wait as this version may become available soon.",
-			ExpectedError:   "unable to process release file as it is missing for release v1.57",
-			ExpectedLabels:  []string{"conformance-product-submission", "unable-to-process"},
-			PullRequestQuery: &suite.PullRequestQuery{
-				Title:  githubql.String("Conformance results for v1.57/coolkube"),
-				Number: githubql.Int(0),
-				Commits: struct {
-					Nodes []struct {
-						Commit struct {
-							Oid    githubql.String
-							Status struct {
-								Contexts []struct {
-									Context githubql.String
-									State   githubql.String
-								}
-							}
-						}
-					}
-				}{
-					Nodes: []struct {
-						Commit struct {
-							Oid    githubql.String
-							Status struct {
-								Contexts []struct {
-									Context githubql.String
-									State   githubql.String
-								}
-							}
-						}
-					}{
-						{
-							Commit: struct {
-								Oid    githubql.String
-								Status struct {
-									Contexts []struct {
-										Context githubql.String
-										State   githubql.String
-									}
-								}
-							}{
-								Oid: githubql.String(""),
-								Status: struct {
-									Contexts []struct {
-										Context githubql.String
-										State   githubql.String
-									}
-								}{
-									Contexts: []struct {
-										Context githubql.String
-										State   githubql.String
-									}{
-										{
-											Context: githubql.String(""),
-											State:   githubql.String(""),
-										},
-									},
-								},
-							},
-						},
-					},
-				},
+		},
+	})
+	prSuite, err := NewPRSuiteForPR(log, ghc, pullRequestQuery)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	productYAML := prSuite.GetFileByFileName("PRODUCT.yaml")
+	if productYAML == nil || !strings.Contains(productYAML.Contents, "coolkube") {
+		t.Fatalf("error: expected the PRODUCT.yaml contents to be served by the stub client")
+	}
+	if contentType := prSuite.PR.ProductYAMLURLDataTypes["website_url"]; contentType != "text/html" {
+		t.Fatalf("error: expected website_url to be resolved by the stub client, got '%v'", contentType)
+	}
+	if len(stub.requests) == 0 {
+		t.Fatalf("error: expected the stub client to have served requests")
+	}
+}
+
+func Test_fetchFileFromURITraversesProxy(t *testing.T) {
+	defaultProxyURL := outboundProxyURL
+	defer func() {
+		outboundProxyURL = defaultProxyURL
+	}()
+	proxiedHosts := []string{}
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHosts = append(proxiedHosts, r.Host)
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte("proxied content")); err != nil {
+			t.Logf("error: sending http response; %v", err)
+		}
+	}))
+	defer proxy.Close()
+	if err := SetOutboundProxyURL(proxy.URL); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	content, resp, err := fetchFileFromURI(context.TODO(), "http://coolkubernetes.com/e2e.log")
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || content != "proxied content" {
+		t.Fatalf("error: expected the proxied content, got '%v' (%v)", content, resp.StatusCode)
+	}
+	foundHost := false
+	for _, host := range proxiedHosts {
+		if host == "coolkubernetes.com" {
+			foundHost = true
+		}
+	}
+	if !foundHost {
+		t.Fatalf("error: expected the request to traverse the proxy; got hosts %v", proxiedHosts)
+	}
+	if err := SetOutboundProxyURL("://not-a-url"); err == nil {
+		t.Fatalf("error: expected an error for an invalid proxy url")
+	}
+}
+
+func TestNewPRSuiteForPRTypedProductYAMLValues(t *testing.T) {
+	common.DataPathPrefix = "../../"
+	productYAMLContents := ""
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(productYAMLContents)); err != nil {
+			t.Logf("error: sending http response; %v", err)
+		}
+	}))
+	defer svr.Close()
+	productYAMLContents = fmt.Sprintf(`vendor: "cool"
+name: "coolkube"
+version: 1.29
+preview: true
+website_url: "%v/website"`, svr.URL)
+	pullRequestQuery := &suite.PullRequestQuery{
+		Number: githubql.Int(1),
+		Repository: struct {
+			Name  githubql.String
+			Owner struct{ Login githubql.String }
+		}{
+			Name: githubql.String("cncf-ci"),
+			Owner: struct{ Login githubql.String }{
+				Login: githubql.String("cncf-ci"),
 			},
 		},
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
 		{
-			Name: "not a conformance pr",
-			PullRequestQuery: &suite.PullRequestQuery{
-				Title: githubql.String("soup recipes for winter"),
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles: []*suite.PullRequestFile{
+				{
+					Name:     "v1.29/coolkube/PRODUCT.yaml",
+					BaseName: "PRODUCT.yaml",
+					BlobURL:  svr.URL + "/PRODUCT.yaml",
+				},
 			},
-			ExpectedComment: "This pull request appears to not be a conformance results submission; Checks will not run.",
-			ExpectedLabels:  []string{"not-conformance-product-submission", "unable-to-process"},
-			ExpectedStatus:  "pending",
 		},
-	} {
-		t.Run(tc.Name, func(t *testing.T) {
-			productYAML := map[string]string{}
-			var productYAMLSupportingFile string
-			for _, file := range tc.SupportingFiles {
-				if file.BaseName == "PRODUCT.yaml" {
-					productYAMLSupportingFile = file.Contents
-				}
-			}
-			if productYAMLSupportingFile != "" {
-				if err := yaml.Unmarshal([]byte(productYAMLSupportingFile), &productYAML); err != nil {
-					t.Fatalf("error: unmarshalling from PRODUCT.yaml supporting file: %v", err)
-				}
-			}
-			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				t.Logf("requesting path '%v'", r.URL.Path)
-				supportingFile := &suite.PullRequestFile{}
-				for _, file := range tc.SupportingFiles {
-					if r.URL.Path == "/"+file.BaseName || r.URL.Path == "/"+file.Name {
-						supportingFile = file
-					}
-				}
-				w.WriteHeader(http.StatusOK)
-				_, err := w.Write([]byte(supportingFile.Contents))
-				if err != nil {
-					t.Fatalf("error: sending http response; %v", err)
-				}
-			}))
-			defer svr.Close()
-			for _, field := range []string{"website_url", "documentation_url"} {
-				if productYAML[field] != "" {
-					productYAML[field] = svr.URL + "/" + productYAML[field]
-				}
-			}
-			productYAMLBytes, err := yaml.Marshal(productYAML)
-			if err != nil {
-				t.Fatalf("error: marshalling new product yaml: %v", err)
-			}
-			for i := range tc.SupportingFiles {
-				tc.SupportingFiles[i].BlobURL = svr.URL + "/" + tc.SupportingFiles[i].BlobURL
-				if tc.SupportingFiles[i].BaseName == "PRODUCT.yaml" {
-					tc.SupportingFiles[i].Contents = string(productYAMLBytes)
-				}
-			}
-			ghc := NewFakeGitHubClient([]*prContext{
-				{
-					PullRequestQuery: tc.PullRequestQuery,
-					SupportingFiles:  tc.SupportingFiles,
-				},
-			})
-			if err := handle(log, ghc, tc.PullRequestQuery); err != nil && !strings.Contains(err.Error(), tc.ExpectedError) {
-				t.Fatalf("unexpected error: %v", err)
-			}
-			if tc.ExpectedComment != "" {
-				found := false
-				got := ""
-				for _, comment := range ghc.PopulatedPullRequests[tc.PullRequestQuery.Number].Comments {
-					if strings.Contains(comment.Body, tc.ExpectedComment) {
-						got = comment.Body
-						found = true
-					}
-				}
-				if !found {
-					t.Fatalf("unexpected comment: want = %v; got %v", tc.ExpectedComment, got)
-				}
-			}
-			if want, got := tc.ExpectedStatus, ghc.PopulatedPullRequests[tc.PullRequestQuery.Number].Status.State; want != "" && want != got {
-				t.Fatalf("unexpected status: want = %v; got = %v", want, got)
-			}
-			prLabels := []string{}
-			for _, l := range ghc.PopulatedPullRequests[tc.PullRequestQuery.Number].PullRequestQuery.Labels.Nodes {
-				prLabels = append(prLabels, string(l.Name))
-			}
-			if !reflect.DeepEqual(tc.ExpectedLabels, prLabels) {
-				t.Fatalf("unexpected labels: want = %v; got = %v", tc.ExpectedLabels, prLabels)
-			}
-		})
+	})
+	prSuite, err := NewPRSuiteForPR(log, ghc, pullRequestQuery)
+	if err != nil {
+		t.Fatalf("error: %v", err)
 	}
-}
-
-func TestNewPullRequestQueryForGithubPullRequest(t *testing.T) {
-	if prq := NewPullRequestQueryForGithubPullRequest(
-		"cncf",
-		"k8s-conformance",
-		0,
-		&github.PullRequest{
-			User: github.User{
-				Login: "cncf-ci",
-			},
-		},
-	); prq == nil {
-		t.Fatalf("PullRequestQuery must never be empty")
+	if contentType := prSuite.PR.ProductYAMLURLDataTypes["website_url"]; contentType == "" {
+		t.Fatalf("error: expected website_url to be resolved even with non-string fields present")
 	}
 }
 
-func TestHandlePullRequestEvent(t *testing.T) {
-	type args struct {
-		log *logrus.Entry
-		pre *github.PullRequestEvent
-	}
-	tests := []struct {
-		name            string
-		supportingFiles []*suite.PullRequestFile
-		args            args
-		wantErr         bool
-	}{
-		{
-			name: "basic",
-			supportingFiles: []*suite.PullRequestFile{
-				{
-					Name:     "v1.30/coolkube/README.md",
-					BaseName: "README.md",
-					Contents: `# coolkube
-> the coolest Kubernetes distribution
+func TestNewPRSuiteForPRCustomCABundle(t *testing.T) {
+	common.DataPathPrefix = "../../"
+	defaultRootCAs := productYAMLURLRootCAs
+	defer func() { productYAMLURLRootCAs = defaultRootCAs }()
 
-## Generating conformance results
+	tlsSvr := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer tlsSvr.Close()
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		productYAML := fmt.Sprintf("vendor: \"cool\"\nname: \"coolkube\"\ndocumentation_url: \"%v/docs\"", tlsSvr.URL)
+		if _, err := w.Write([]byte(productYAML)); err != nil {
+			t.Logf("error: sending http response; %v", err)
+		}
+	}))
+	defer svr.Close()
 
-1. create a coolkube cluster
-2. sonobuoy run --wait && sonobuoy results "$(sonobuoy retrieve)" && sonobuoy delete --wait`,
-					BlobURL: "README.md",
-				},
-				{
-					Name:     "v1.30/coolkube/PRODUCT.yaml",
-					BaseName: "PRODUCT.yaml",
-					Contents: `vendor: "cool"
-name: "coolkube"
-version: "v1.30"
-type: "distribution"
-description: "it's just all-round cool and probably the best k8s, idk"
-website_url: "website_url"
-documentation_url: "docs"
-contact_email_address: "sales@coolkubernetes.com"`,
-					BlobURL: "PRODUCT.yaml",
-				},
-				{
-					Name:     "v1.30/coolkube/e2e.log",
-					BaseName: "e2e.log",
-					Contents: "",
-					BlobURL:  "e2e.log",
-				},
-				{
-					Name:     "v1.30/coolkube/junit_01.xml",
-					BaseName: "junit_01.xml",
-					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01xml,
-					BlobURL:  "junit_01.xml",
-				},
-			},
-			args: args{
-				log: log,
-				pre: &github.PullRequestEvent{
-					Action: github.PullRequestActionOpened,
-					Repo: github.Repo{
-						Owner: github.User{
-							Login: "cncf",
-						},
-						Name: "k8s-conformance",
-					},
-					Number: 12345,
-					PullRequest: github.PullRequest{
-						Title: "Conformance results for v1.27/coolkube",
-						User: github.User{
-							Login: "example",
+	newPRContexts := func() []*prContext {
+		return []*prContext{
+			{
+				PullRequestQuery: &suite.PullRequestQuery{
+					Number: githubql.Int(1),
+					Repository: struct {
+						Name  githubql.String
+						Owner struct{ Login githubql.String }
+					}{
+						Name: githubql.String("cncf-ci"),
+						Owner: struct{ Login githubql.String }{
+							Login: githubql.String("cncf-ci"),
 						},
 					},
 				},
-			},
-		},
-		{
-			name: "request not open or reopen",
-			args: args{
-				log: log,
-				pre: &github.PullRequestEvent{
-					Action: github.PullRequestActionClosed,
+				SupportingFiles: []*suite.PullRequestFile{
+					{
+						Name:     "v1.30/coolkube/PRODUCT.yaml",
+						BaseName: "PRODUCT.yaml",
+						BlobURL:  svr.URL + "/PRODUCT.yaml",
+					},
 				},
 			},
-		},
+		}
 	}
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			productYAML := map[string]string{}
-			var productYAMLSupportingFile string
-			for _, file := range tt.supportingFiles {
-				if file.BaseName == "PRODUCT.yaml" {
-					productYAMLSupportingFile = file.Contents
-				}
-			}
-			if productYAMLSupportingFile != "" {
-				if err := yaml.Unmarshal([]byte(productYAMLSupportingFile), &productYAML); err != nil {
-					t.Fatalf("error: unmarshalling from PRODUCT.yaml supporting file: %v", err)
-				}
+
+	// without the bundle the server's certificate isn't trusted and the
+	// field doesn't resolve
+	prContexts := newPRContexts()
+	prSuite, err := NewPRSuiteForPR(log, NewFakeGitHubClient(prContexts), prContexts[0].PullRequestQuery)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if contentType := prSuite.PR.ProductYAMLURLDataTypes["documentation_url"]; contentType != "" {
+		t.Fatalf("error: expected documentation_url not to resolve without the CA bundle; got '%v'", contentType)
+	}
+
+	caBundlePath := path.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsSvr.Certificate().Raw})
+	if err := os.WriteFile(caBundlePath, caPEM, 0644); err != nil {
+		t.Fatalf("error: writing the CA bundle; %v", err)
+	}
+	if err := SetProductYAMLURLCABundle(caBundlePath); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	prContexts = newPRContexts()
+	prSuite, err = NewPRSuiteForPR(log, NewFakeGitHubClient(prContexts), prContexts[0].PullRequestQuery)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if contentType := prSuite.PR.ProductYAMLURLDataTypes["documentation_url"]; contentType != "text/html" {
+		t.Fatalf("error: expected documentation_url to resolve with the CA bundle; got '%v'", contentType)
+	}
+
+	if err := SetProductYAMLURLCABundle(path.Join(t.TempDir(), "missing.pem")); err == nil {
+		t.Fatalf("error: expected an error for a missing CA bundle")
+	}
+}
+
+func TestNewPRSuiteForPRBoundedRedirects(t *testing.T) {
+	common.DataPathPrefix = "../../"
+	documentationPath := ""
+	var svr *httptest.Server
+	svr = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/PRODUCT.yaml":
+			w.WriteHeader(http.StatusOK)
+			productYAML := fmt.Sprintf("vendor: \"cool\"\nname: \"coolkube\"\ndocumentation_url: \"%v%v\"", svr.URL, documentationPath)
+			if _, err := w.Write([]byte(productYAML)); err != nil {
+				t.Logf("error: sending http response; %v", err)
 			}
-			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				t.Logf("requesting path '%v'", r.URL.Path)
-				supportingFile := &suite.PullRequestFile{}
-				for _, file := range tt.supportingFiles {
-					if r.URL.Path == "/"+file.BaseName || r.URL.Path == "/"+file.Name {
-						supportingFile = file
-					}
-				}
+		case r.URL.Path == "/loop":
+			http.Redirect(w, r, svr.URL+"/loop-elsewhere", http.StatusFound)
+		case r.URL.Path == "/loop-elsewhere":
+			http.Redirect(w, r, svr.URL+"/loop", http.StatusFound)
+		case strings.HasPrefix(r.URL.Path, "/chain/"):
+			hop, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/chain/"))
+			if err != nil || hop >= 15 {
 				w.WriteHeader(http.StatusOK)
-				_, err := w.Write([]byte(supportingFile.Contents))
-				if err != nil {
-					t.Fatalf("error: sending http response; %v", err)
-				}
-			}))
-			defer svr.Close()
-			for _, field := range []string{"website_url", "documentation_url"} {
-				if productYAML[field] != "" {
-					productYAML[field] = svr.URL + "/" + productYAML[field]
-				}
-			}
-			productYAMLBytes, err := yaml.Marshal(productYAML)
-			if err != nil {
-				t.Fatalf("error: marshalling new product yaml: %v", err)
+				return
 			}
-			for i := range tt.supportingFiles {
-				tt.supportingFiles[i].BlobURL = svr.URL + "/" + tt.supportingFiles[i].BlobURL
-				if tt.supportingFiles[i].BaseName == "PRODUCT.yaml" {
-					tt.supportingFiles[i].Contents = string(productYAMLBytes)
-				}
+			http.Redirect(w, r, fmt.Sprintf("%v/chain/%v", svr.URL, hop+1), http.StatusFound)
+		case r.URL.Path == "/offsite":
+			http.Redirect(w, r, strings.Replace(svr.URL, "127.0.0.1", "localhost", 1)+"/docs", http.StatusFound)
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer svr.Close()
+
+	type testCase struct {
+		Name                 string
+		Path                 string
+		ExpectedReason       string
+		ExpectedRedirectHost string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:           "redirect loop",
+			Path:           "/loop",
+			ExpectedReason: "the URL redirects in a loop",
+		},
+		{
+			Name:           "excessive redirect chain",
+			Path:           "/chain/0",
+			ExpectedReason: "the URL redirects too many times",
+		},
+		{
+			Name:                 "redirect to a different domain",
+			Path:                 "/offsite",
+			ExpectedRedirectHost: "localhost",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			documentationPath = tc.Path
+			pullRequestQuery := &suite.PullRequestQuery{
+				Number: githubql.Int(1),
+				Repository: struct {
+					Name  githubql.String
+					Owner struct{ Login githubql.String }
+				}{
+					Name: githubql.String("cncf-ci"),
+					Owner: struct{ Login githubql.String }{
+						Login: githubql.String("cncf-ci"),
+					},
+				},
 			}
 			ghc := NewFakeGitHubClient([]*prContext{
 				{
-					PullRequestQuery: NewPullRequestQueryForGithubPullRequest(tt.args.pre.Repo.Owner.Login, tt.args.pre.Repo.Name, tt.args.pre.Number, &tt.args.pre.PullRequest),
-					SupportingFiles:  tt.supportingFiles,
+					PullRequestQuery: pullRequestQuery,
+					SupportingFiles: []*suite.PullRequestFile{
+						{
+							Name:     "v1.30/coolkube/PRODUCT.yaml",
+							BaseName: "PRODUCT.yaml",
+							BlobURL:  svr.URL + "/PRODUCT.yaml",
+						},
+					},
 				},
 			})
-			if err := HandlePullRequestEvent(tt.args.log, ghc, tt.args.pre); (err != nil) != tt.wantErr {
-				t.Errorf("HandlePullRequestEvent() error = %v, wantErr %v", err, tt.wantErr)
+			prSuite, err := NewPRSuiteForPR(log, ghc, pullRequestQuery)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if reason := prSuite.PR.ProductYAMLURLFailureReasons["documentation_url"]; reason != tc.ExpectedReason {
+				t.Fatalf("error: expected failure reason '%v' for documentation_url; got '%v'", tc.ExpectedReason, reason)
+			}
+			if host := prSuite.PR.ProductYAMLURLRedirectHosts["documentation_url"]; host != tc.ExpectedRedirectHost {
+				t.Fatalf("error: expected redirect host '%v' for documentation_url; got '%v'", tc.ExpectedRedirectHost, host)
 			}
 		})
 	}
 }
 
-func TestHandleIssueCommentEvent(t *testing.T) {
-	type args struct {
-		log *logrus.Entry
-		ice *github.IssueCommentEvent
+func Test_search(t *testing.T) {
+	type testCase struct {
+		Name                string
+		PullRequestQuery    *suite.PullRequestQuery
+		ExpectedErrorString string
 	}
-	tests := []struct {
-		name             string
-		args             args
-		pullRequestQuery *suite.PullRequestQuery
-		supportingFiles  []*suite.PullRequestFile
-		wantErr          bool
-	}{
+	for _, tc := range []testCase{
 		{
-			name: "basic",
-			pullRequestQuery: &suite.PullRequestQuery{
-				Number: githubql.Int(12345),
-				Title:  githubql.String("Conformance results for v1.27/coolkube"),
-				Commits: struct {
-					Nodes []struct {
-						Commit struct {
-							Oid    githubql.String
-							Status struct {
-								Contexts []struct {
-									Context githubql.String
-									State   githubql.String
-								}
-							}
-						}
-					}
+			Name: "complete result",
+			PullRequestQuery: &suite.PullRequestQuery{
+				Number: githubql.Int(1),
+				Author: struct{ Login githubql.String }{
+					Login: githubql.String("cncf"),
+				},
+				Repository: struct {
+					Name  githubql.String
+					Owner struct{ Login githubql.String }
 				}{
-					Nodes: []struct {
-						Commit struct {
-							Oid    githubql.String
-							Status struct {
-								Contexts []struct {
-									Context githubql.String
-									State   githubql.String
-								}
-							}
-						}
-					}{
-						{
-							Commit: struct {
-								Oid    githubql.String
-								Status struct {
-									Contexts []struct {
-										Context githubql.String
-										State   githubql.String
-									}
-								}
-							}{
-								Oid: githubql.String(""),
-								Status: struct {
-									Contexts []struct {
-										Context githubql.String
-										State   githubql.String
-									}
-								}{
-									Contexts: []struct {
-										Context githubql.String
-										State   githubql.String
-									}{
-										{
-											Context: githubql.String(""),
-											State:   githubql.String(""),
-										},
-									},
-								},
-							},
-						},
+					Name: githubql.String("k8s-conformance"),
+					Owner: struct{ Login githubql.String }{
+						Login: githubql.String("cncf"),
 					},
 				},
 			},
-			supportingFiles: []*suite.PullRequestFile{
+		},
+		{
+			Name: "org does not exist",
+			PullRequestQuery: &suite.PullRequestQuery{
+				Number: githubql.Int(1),
+				Author: struct{ Login githubql.String }{
+					Login: githubql.String("nil"),
+				},
+				Repository: struct {
+					Name  githubql.String
+					Owner struct{ Login githubql.String }
+				}{
+					Name: githubql.String("k8s-conformance"),
+					Owner: struct{ Login githubql.String }{
+						Login: githubql.String("nil"),
+					},
+				},
+			},
+			ExpectedErrorString: "org does not exist",
+		},
+		{
+			Name:                "empty",
+			PullRequestQuery:    nil,
+			ExpectedErrorString: "empty pr",
+		},
+	} {
+		ghc := NewFakeGitHubClient([]*prContext{
+			{
+				PullRequestQuery: tc.PullRequestQuery,
+			},
+		})
+		var org string
+		if tc.PullRequestQuery != nil {
+			org = string(tc.PullRequestQuery.Repository.Owner.Login)
+		}
+		prs, _, err := search(context.TODO(), log, ghc, "archived:false is:pr is:open repo:\"k8s-conformance\"", org)
+		t.Logf("tc(%v) has error %v", tc.Name, err != nil)
+		if err != nil && !strings.Contains(err.Error(), tc.ExpectedErrorString) {
+			t.Fatalf("error searching for PRs: %v", err)
+		}
+		t.Logf("tc(%v) %+v\n", tc.Name, prs)
+	}
+}
+
+func Test_searchRateLimited(t *testing.T) {
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Number: githubql.Int(1),
+		Repository: struct {
+			Name  githubql.String
+			Owner struct{ Login githubql.String }
+		}{
+			Name: githubql.String("k8s-conformance"),
+			Owner: struct{ Login githubql.String }{
+				Login: githubql.String("cncf"),
+			},
+		},
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+		},
+	})
+	remaining := 1
+	resetAt := time.Now().Add(time.Hour)
+	ghc.RateLimitRemaining = &remaining
+	ghc.RateLimitResetAt = &resetAt
+	ctx, cancel := context.WithTimeout(context.TODO(), 100*time.Millisecond)
+	defer cancel()
+	prs, truncated, err := search(ctx, log, ghc, "archived:false is:pr is:open repo:\"k8s-conformance\"", "cncf")
+	if err != nil {
+		t.Fatalf("error searching for PRs: %v", err)
+	}
+	if !truncated {
+		t.Fatal("error: expected the sweep to be truncated when the rate limit does not reset in time")
+	}
+	if len(prs) != 1 {
+		t.Fatalf("error: expected the partial results from the first page; got %v", len(prs))
+	}
+}
+
+// flakyFakeGitHubClient fails search queries with a transient error until
+// the configured number of failures have been served
+type flakyFakeGitHubClient struct {
+	*FakeGitHubClient
+	failures int
+	attempts int
+}
+
+func (f *flakyFakeGitHubClient) QueryWithGitHubAppsSupport(ctx context.Context, sq interface{}, vars map[string]interface{}, org string) error {
+	f.attempts++
+	if f.attempts <= f.failures {
+		return fmt.Errorf("non-200 OK status code: 403 Forbidden; you have triggered an abuse detection mechanism")
+	}
+	return f.FakeGitHubClient.QueryWithGitHubAppsSupport(ctx, sq, vars, org)
+}
+
+func Test_searchRetriesTransientErrors(t *testing.T) {
+	originalBackoff := searchRetryBackoff
+	searchRetryBackoff = time.Millisecond * 10
+	defer func() {
+		searchRetryBackoff = originalBackoff
+	}()
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Number: githubql.Int(1),
+	}
+	ghc := &flakyFakeGitHubClient{
+		FakeGitHubClient: NewFakeGitHubClient([]*prContext{
+			{
+				PullRequestQuery: pullRequestQuery,
+			},
+		}),
+		failures: 1,
+	}
+	prs, truncated, err := search(context.TODO(), log, ghc, "archived:false is:pr is:open repo:\"k8s-conformance\"", "cncf")
+	if err != nil {
+		t.Fatalf("error: an abuse response followed by success must not surface an error; %v", err)
+	}
+	if truncated {
+		t.Fatal("error: expected the sweep to complete")
+	}
+	// the fake serves the PR on each of its two pages
+	if len(prs) != 2 {
+		t.Fatalf("error: expected the results after the retry; got %v", len(prs))
+	}
+	if ghc.attempts != 3 {
+		t.Fatalf("error: expected 3 attempts; got %v", ghc.attempts)
+	}
+}
+
+func Test_searchErrorClassification(t *testing.T) {
+	if searchErrorIsRetryable(fmt.Errorf("could not resolve to a Repository")) {
+		t.Fatal("error: a permanent error must not be retryable")
+	}
+	if !searchErrorIsRetryable(fmt.Errorf("you have exceeded a secondary rate limit")) {
+		t.Fatal("error: a secondary rate limit must be retryable")
+	}
+	if !searchErrorIsRetryable(fmt.Errorf("non-200 OK status code: 502 Bad Gateway; status code 502")) {
+		t.Fatal("error: a server error must be retryable")
+	}
+	wait, ok := searchErrorRetryAfter(fmt.Errorf("abuse detection mechanism; Retry-After: 42"))
+	if !ok || wait != time.Second*42 {
+		t.Fatalf("error: expected a 42s wait from the Retry-After hint; got %v (%v)", wait, ok)
+	}
+	if _, ok := searchErrorRetryAfter(fmt.Errorf("some other error")); ok {
+		t.Fatal("error: expected no Retry-After hint")
+	}
+}
+
+func TestNewPRSuiteForPR(t *testing.T) {
+	type testCase struct {
+		Name                string
+		PullRequestQuery    *suite.PullRequestQuery
+		Labes               []github.Label
+		SupportingFiles     []*suite.PullRequestFile
+		ExpectedErrorString string
+	}
+
+	common.DataPathPrefix = "../../"
+
+	for _, tc := range []testCase{
+		{
+			Name: "valid pull request entry",
+			Labes: []github.Label{
+				{
+					Name: "conformance-product-submission",
+				},
+			},
+			PullRequestQuery: &suite.PullRequestQuery{
+				Number: githubql.Int(1),
+				Repository: struct {
+					Name  githubql.String
+					Owner struct{ Login githubql.String }
+				}{
+					Name: githubql.String("cncf-ci"),
+					Owner: struct{ Login githubql.String }{
+						Login: githubql.String("cncf-ci"),
+					},
+				},
+			},
+			SupportingFiles: []*suite.PullRequestFile{
 				{
 					Name:     "v1.30/coolkube/README.md",
 					BaseName: "README.md",
-					Contents: `# coolkube
-> the coolest Kubernetes distribution
-
-## Generating conformance results
-
-1. create a coolkube cluster
-2. sonobuoy run --wait && sonobuoy results "$(sonobuoy retrieve)" && sonobuoy delete --wait`,
-					BlobURL: "README.md",
+					BlobURL:  "README.md",
+					Contents: `# CoolKube`,
 				},
 				{
 					Name:     "v1.30/coolkube/PRODUCT.yaml",
 					BaseName: "PRODUCT.yaml",
+					BlobURL:  "PRODUCT.yaml",
 					Contents: `vendor: "cool"
 name: "coolkube"
 version: "v1.30"
@@ -1312,57 +2150,2512 @@ description: "it's just all-round cool and probably the best k8s, idk"
 website_url: "website_url"
 documentation_url: "docs"
 contact_email_address: "sales@coolkubernetes.com"`,
-					BlobURL: "PRODUCT.yaml",
-				},
-				{
-					Name:     "v1.30/coolkube/e2e.log",
-					BaseName: "e2e.log",
-					Contents: "",
-					BlobURL:  "e2e.log",
 				},
 				{
 					Name:     "v1.30/coolkube/junit_01.xml",
 					BaseName: "junit_01.xml",
-					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01xml,
 					BlobURL:  "junit_01.xml",
+					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01xml,
 				},
-			},
-			args: args{
-				log: log,
-				ice: &github.IssueCommentEvent{
-					Action: github.IssueCommentActionCreated,
-					Issue: github.Issue{
-						PullRequest: &struct{}{},
-						Number:      12345,
-					},
-					Comment: github.IssueComment{},
-					Repo: github.Repo{
-						Owner: github.User{
-							Login: "cncf",
-						},
-						Name: "k8s-conformance",
-					},
-				},
-			},
-		},
-		{
-			name: "not a pr",
-			args: args{
-				log: log,
-				ice: &github.IssueCommentEvent{
-					Action: github.IssueCommentActionCreated,
-					Issue: github.Issue{
-						Number: 101010,
-					},
+				{
+					Name:     "v1.30/coolkube/e2e.log",
+					BaseName: "e2e.log",
+					BlobURL:  "e2e.log",
+					Contents: `cool!`,
 				},
 			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			productYAML := map[string]string{}
-			var productYAMLSupportingFile string
-			for _, file := range tt.supportingFiles {
+	} {
+		productYAML := map[string]string{}
+		var productYAMLSupportingFile string
+		for _, file := range tc.SupportingFiles {
+			if file.BaseName == "PRODUCT.yaml" {
+				productYAMLSupportingFile = file.Contents
+			}
+		}
+		if productYAMLSupportingFile != "" {
+			if err := yaml.Unmarshal([]byte(productYAMLSupportingFile), &productYAML); err != nil {
+				t.Fatalf("error: unmarshalling from PRODUCT.yaml supporting file: %v", err)
+			}
+		}
+
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("requesting path '%v'", r.URL.Path)
+			supportingFile := &suite.PullRequestFile{}
+			for _, file := range tc.SupportingFiles {
+				if r.URL.Path == "/"+file.BaseName || r.URL.Path == "/"+file.Name {
+					supportingFile = file
+				}
+			}
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(supportingFile.Contents))
+			if err != nil {
+				t.Fatalf("error: sending http response; %v", err)
+			}
+		}))
+		defer svr.Close()
+		for _, field := range []string{"website_url", "documentation_url"} {
+			if productYAML[field] != "" {
+				productYAML[field] = svr.URL + "/" + productYAML[field]
+			}
+		}
+		productYAMLBytes, err := yaml.Marshal(productYAML)
+		if err != nil {
+			t.Fatalf("error: marshalling new product yaml: %v", err)
+		}
+		for i := range tc.SupportingFiles {
+			tc.SupportingFiles[i].BlobURL = svr.URL + "/" + tc.SupportingFiles[i].BlobURL
+			if tc.SupportingFiles[i].BaseName == "PRODUCT.yaml" {
+				tc.SupportingFiles[i].Contents = string(productYAMLBytes)
+			}
+		}
+		ghc := NewFakeGitHubClient([]*prContext{
+			{
+				PullRequestQuery: tc.PullRequestQuery,
+				SupportingFiles:  tc.SupportingFiles,
+			},
+		})
+		prSuite, err := NewPRSuiteForPR(log, ghc, tc.PullRequestQuery)
+		if err != nil && strings.Contains(err.Error(), tc.ExpectedErrorString) {
+			t.Fatalf("unexpected error in testcase '%v': %v", tc.Name, err)
+		}
+		t.Logf("prSuite: %+v\n", prSuite)
+	}
+}
+
+func TestGetGodogPaths(t *testing.T) {
+	paths := GetGodogPaths()
+	found := false
+	for _, p := range paths {
+		if p == "../../kodata/features/" || p == "./kodata/features/" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Fatalf("error: unable to find features folder for godog paths")
+	}
+
+	tempDir := t.TempDir()
+	t.Setenv("VERIFY_CONFORMANCE_FEATURE_PATHS", tempDir+":/somewhere-that-does-not-exist")
+	paths = GetGodogPaths()
+	if len(paths) == 0 || paths[0] != tempDir {
+		t.Fatalf("error: expected the feature path from the environment (%v) to be considered first; got %v", tempDir, paths)
+	}
+	for _, p := range paths {
+		if p == "/somewhere-that-does-not-exist" {
+			t.Fatalf("error: expected non-existent feature paths to be filtered out; got %v", paths)
+		}
+	}
+}
+
+func Test_labelIsManaged(t *testing.T) {
+	type testCase struct {
+		Label          string
+		ExpectedResult bool
+	}
+
+	for _, tc := range []testCase{
+		{
+			Label:          "conformance-product-submission",
+			ExpectedResult: true,
+		},
+		{
+			Label:          "not-verifiable",
+			ExpectedResult: true,
+		},
+		{
+			Label:          "release-documents-checked",
+			ExpectedResult: true,
+		},
+		{
+			Label:          "required-tests-missing",
+			ExpectedResult: true,
+		},
+		{
+			Label:          "evidence-missing",
+			ExpectedResult: true,
+		},
+		{
+			Label:          "unable-to-process",
+			ExpectedResult: true,
+		},
+		{
+			Label:          "release-candidate",
+			ExpectedResult: true,
+		},
+		{
+			Label:          "some-kinda-label",
+			ExpectedResult: false,
+		},
+		{
+			Label:          "non-managed",
+			ExpectedResult: false,
+		},
+	} {
+		if result := labelIsManaged(tc.Label); result != tc.ExpectedResult {
+			t.Fatalf("error: label (%v) not expected to be managed", tc.Label)
+		}
+	}
+}
+
+func Test_labelIsVersionLabel(t *testing.T) {
+	type testCase struct {
+		Label          string
+		Version        string
+		ExpectedResult bool
+	}
+
+	for _, tc := range []testCase{
+		{
+			Label:          "release-v1.27",
+			Version:        "v1.27",
+			ExpectedResult: true,
+		},
+		{
+			Label:          "release-v1.26",
+			Version:        "v1.26",
+			ExpectedResult: true,
+		},
+		{
+			Label:          "no-failed-tests-v1.27",
+			Version:        "v1.27",
+			ExpectedResult: true,
+		},
+		{
+			Label:          "no-failed-tests-v1.26",
+			Version:        "v1.26",
+			ExpectedResult: true,
+		},
+		{
+			Label:          "tests-verified-v1.27",
+			Version:        "v1.27",
+			ExpectedResult: true,
+		},
+		{
+			Label:          "release-v1.27.3",
+			Version:        "v1.27",
+			ExpectedResult: true,
+		},
+		{
+			// managed as a fixed label rather than a version label
+			Label:          "release-candidate",
+			Version:        "v1.27",
+			ExpectedResult: false,
+		},
+		{
+			Label:          "release-note",
+			Version:        "v1.27",
+			ExpectedResult: false,
+		},
+		{
+			Label:          "release-note-needed",
+			Version:        "v1.27",
+			ExpectedResult: false,
+		},
+		{
+			Label:          "am-i-a-label-v1.27",
+			Version:        "v1.27",
+			ExpectedResult: false,
+		},
+		{
+			Label:          "thing",
+			Version:        "v1.27",
+			ExpectedResult: false,
+		},
+	} {
+		if result := labelIsVersionLabel(tc.Label, tc.Version); result != tc.ExpectedResult {
+			t.Fatalf("error: version label (%v) does not match expected result (%v)", tc.Label, tc.ExpectedResult)
+		}
+	}
+}
+
+func Test_labelIsFileLabel(t *testing.T) {
+	type testCase struct {
+		Name           string
+		Label          string
+		MissingFiles   []string
+		ExpectedResult bool
+	}
+
+	for _, tc := range []testCase{
+		{
+			Label:          "missing-file-README.md",
+			MissingFiles:   []string{"README.md"},
+			ExpectedResult: true,
+		},
+		{
+			Label:          "missing-file-e2e.log",
+			MissingFiles:   []string{"e2e.log"},
+			ExpectedResult: true,
+		},
+		{
+			Label:          "missing-file-junit_01.xml",
+			MissingFiles:   []string{"junit_01.xml"},
+			ExpectedResult: true,
+		},
+		{
+			Label:          "missing-file-PRODUCT.yaml",
+			MissingFiles:   []string{"PRODUCT.yaml"},
+			ExpectedResult: true,
+		},
+		{
+			Label:          "missing-file-README.md",
+			ExpectedResult: true,
+		},
+		{
+			Label:          "missing-file-e2e.log",
+			ExpectedResult: true,
+		},
+		{
+			Label:          "missing-file-junit_01.xml",
+			ExpectedResult: true,
+		},
+		{
+			Label:          "missing-file-PRODUCT.yaml",
+			ExpectedResult: true,
+		},
+		{
+			Label:          "hi-im-a-label",
+			ExpectedResult: false,
+		},
+		{
+			Label:          "missing-fil-PRODUCT.yaml",
+			ExpectedResult: false,
+		},
+	} {
+		if result := labelIsFileLabel(tc.Label, tc.MissingFiles); result != tc.ExpectedResult {
+			t.Fatalf("error: file label is not expected for %v (%v) with result (%v)", tc.Label, tc.ExpectedResult, result)
+		}
+	}
+}
+
+func TestSetManagedLabels(t *testing.T) {
+	defaultLabels := managedPRLabels
+	defaultVersionTemplates := managedPRLabelTemplatesWithVersion
+	defaultFileTemplates := managedPRLabelTemplatesWithFileName
+	defer func() {
+		managedPRLabels = defaultLabels
+		managedPRLabelTemplatesWithVersion = defaultVersionTemplates
+		managedPRLabelTemplatesWithFileName = defaultFileTemplates
+	}()
+
+	if err := SetManagedLabels([]string{"verified-submission"}, []string{"for-release-%v"}, []string{"needs-file-%v"}); err != nil {
+		t.Fatalf("error: setting a custom label set: %v", err)
+	}
+	if !labelIsManaged("verified-submission") {
+		t.Fatalf("error: custom label 'verified-submission' should be managed")
+	}
+	if labelIsManaged("conformance-product-submission") {
+		t.Fatalf("error: default label 'conformance-product-submission' should no longer be managed")
+	}
+	if !labelIsVersionLabel("for-release-v1.29", "v1.29") {
+		t.Fatalf("error: custom version label 'for-release-v1.29' should match")
+	}
+	if labelIsVersionLabel("release-v1.29", "v1.29") {
+		t.Fatalf("error: default version label 'release-v1.29' should no longer match")
+	}
+	if !labelIsFileLabel("needs-file-e2e.log", []string{"e2e.log"}) {
+		t.Fatalf("error: custom file label 'needs-file-e2e.log' should match")
+	}
+	if labelIsFileLabel("missing-file-e2e.log", []string{"e2e.log"}) {
+		t.Fatalf("error: default file label 'missing-file-e2e.log' should no longer match")
+	}
+
+	if err := SetManagedLabels([]string{" "}, nil, nil); err == nil {
+		t.Fatalf("error: expected an empty label name to be rejected")
+	}
+	if err := SetManagedLabels(nil, []string{"for-release"}, nil); err == nil {
+		t.Fatalf("error: expected a version label template without a placeholder to be rejected")
+	}
+	if err := SetManagedLabels(nil, nil, []string{"needs-file"}); err == nil {
+		t.Fatalf("error: expected a file label template without a placeholder to be rejected")
+	}
+	if !labelIsManaged("verified-submission") {
+		t.Fatalf("error: an invalid configuration should leave the current label set in place")
+	}
+
+	if err := SetManagedLabels(nil, nil, nil); err != nil {
+		t.Fatalf("error: empty slices should be accepted and leave the current values in place: %v", err)
+	}
+	if !labelIsVersionLabel("for-release-v1.29", "v1.29") {
+		t.Fatalf("error: empty slices should not reset the configured templates")
+	}
+}
+
+func Test_removeSliceOfStringsFromStringSlice(t *testing.T) {
+	type testCase struct {
+		Input          []string
+		Remove         []string
+		ExpectedOutput []string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Input:          []string{"a", "b", "c", "d", "e"},
+			Remove:         []string{"b", "e"},
+			ExpectedOutput: []string{"a", "c", "d"},
+		},
+	} {
+		result := removeSliceOfStringsFromStringSlice(tc.Input, tc.Remove)
+		if len(result) != len(tc.ExpectedOutput) {
+			t.Fatalf("error: slice (%v) length is mismatching (%v)", len(result), len(tc.ExpectedOutput))
+		}
+		itemsMatching := []string{}
+		for _, i := range tc.ExpectedOutput {
+			for _, r := range result {
+				if i == r {
+					itemsMatching = append(itemsMatching, r)
+				}
+			}
+		}
+		if len(itemsMatching) != len(tc.ExpectedOutput) {
+			t.Fatalf("error: items matching count (%v) doesn't equal the expected output count (%v)", len(itemsMatching), len(tc.ExpectedOutput))
+		}
+	}
+}
+
+func Test_handle(t *testing.T) {
+	if err := os.Setenv("KO_DATA_PATH", "./../../kodata"); err != nil {
+		log.Fatalf("failed to set env: %v", err)
+	}
+	type testCase struct {
+		Name                    string
+		KubernetesVersion       *string
+		KubernetesVersionLatest *string
+		PullRequestQuery        *suite.PullRequestQuery
+		SupportingFiles         []*suite.PullRequestFile
+		Labels                  []string
+		ExpectedLabels          []string
+		ExpectedComment         string
+		ExpectedStatus          string
+		ExpectedFirstStatus     string
+		ExpectedError           string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:                    "valid submission",
+			Labels:                  []string{"conformance-product-submission"},
+			KubernetesVersion:       common.Pointer("v1.30"),
+			KubernetesVersionLatest: common.Pointer("v1.30"),
+			ExpectedComment:         "have passed for the submission",
+			ExpectedStatus:          "success",
+			ExpectedFirstStatus:     "pending",
+			ExpectedLabels:          []string{"conformance-product-submission", "tests-verified-v1.30", "no-failed-tests-v1.30", "release-v1.30", "release-documents-checked"},
+			SupportingFiles: []*suite.PullRequestFile{
+				{
+					Name:     "v1.30/coolkube/README.md",
+					BaseName: "README.md",
+					Contents: `# coolkube
+> the coolest Kubernetes distribution
+
+## Generating conformance results
+
+1. create a coolkube cluster
+2. sonobuoy run --wait && sonobuoy results "$(sonobuoy retrieve)" && sonobuoy delete --wait`,
+					BlobURL: "README.md",
+				},
+				{
+					Name:     "v1.30/coolkube/PRODUCT.yaml",
+					BaseName: "PRODUCT.yaml",
+					Contents: `vendor: "cool"
+name: "coolkube"
+version: "v1.30"
+type: "distribution"
+description: "it's just all-round cool and probably the best k8s, idk"
+website_url: "website_url"
+documentation_url: "docs"
+contact_email_address: "sales@coolkubernetes.com"`,
+					BlobURL: "PRODUCT.yaml",
+				},
+				{
+					Name:     "v1.30/coolkube/e2e.log",
+					BaseName: "e2e.log",
+					Contents: "Ran 2 of 2 Specs in 10.0 seconds\nSUCCESS! -- 2 Passed | 0 Failed | 0 Pending | 0 Skipped",
+					BlobURL:  "e2e.log",
+				},
+				{
+					Name:     "v1.30/coolkube/junit_01.xml",
+					BaseName: "junit_01.xml",
+					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01xml,
+					BlobURL:  "junit_01.xml",
+				},
+			},
+			PullRequestQuery: &suite.PullRequestQuery{
+				Title:  githubql.String("Conformance results for v1.30/coolkube"),
+				Number: githubql.Int(0),
+				Commits: struct {
+					Nodes []struct {
+						Commit struct {
+							Oid    githubql.String
+							Status struct {
+								Contexts []struct {
+									Context githubql.String
+									State   githubql.String
+								}
+							}
+						}
+					}
+				}{
+					Nodes: []struct {
+						Commit struct {
+							Oid    githubql.String
+							Status struct {
+								Contexts []struct {
+									Context githubql.String
+									State   githubql.String
+								}
+							}
+						}
+					}{
+						{
+							Commit: struct {
+								Oid    githubql.String
+								Status struct {
+									Contexts []struct {
+										Context githubql.String
+										State   githubql.String
+									}
+								}
+							}{
+								Oid: githubql.String(""),
+								Status: struct {
+									Contexts []struct {
+										Context githubql.String
+										State   githubql.String
+									}
+								}{
+									Contexts: []struct {
+										Context githubql.String
+										State   githubql.String
+									}{
+										{
+											Context: githubql.String(""),
+											State:   githubql.String(""),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:                    "valid submission for unreleased version",
+			Labels:                  []string{"conformance-product-submission"},
+			KubernetesVersion:       common.Pointer("v1.57"),
+			KubernetesVersionLatest: common.Pointer("v1.57"),
+			SupportingFiles: []*suite.PullRequestFile{
+				{
+					Name:     "v1.57/coolkube/README.md",
+					BaseName: "README.md",
+					Contents: `# coolkube
+> the coolest Kubernetes distribution
+
+## Generating conformance results
+
+1. create a coolkube cluster
+2. sonobuoy run --wait && sonobuoy results "$(sonobuoy retrieve)" && sonobuoy delete --wait`,
+					BlobURL: "README.md",
+				},
+				{
+					Name:     "v1.57/coolkube/PRODUCT.yaml",
+					BaseName: "PRODUCT.yaml",
+					Contents: `vendor: "cool"
+name: "coolkube"
+version: "v1.57"
+type: "distribution"
+description: "it's just all-round cool and probably the best k8s, idk"
+website_url: "website_url"
+documentation_url: "docs"
+contact_email_address: "sales@coolkubernetes.com"`,
+					BlobURL: "PRODUCT.yaml",
+				},
+				{
+					Name:     "v1.57/coolkube/e2e.log",
+					BaseName: "e2e.log",
+					Contents: "",
+					BlobURL:  "e2e.log",
+				},
+				{
+					Name:     "v1.57/coolkube/junit_01.xml",
+					BaseName: "junit_01.xml",
+					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01xml,
+					BlobURL:  "junit_01.xml",
+				},
+			},
+			ExpectedComment: "The release version v1.57 is valid, but its conformance metadata has not yet been published; the checks will run once it becomes available.",
+			ExpectedError:   "unable to process release file as it is missing for release v1.57",
+			ExpectedLabels:  []string{"conformance-product-submission", "release-not-yet-available"},
+			PullRequestQuery: &suite.PullRequestQuery{
+				Title:  githubql.String("Conformance results for v1.57/coolkube"),
+				Number: githubql.Int(0),
+				Commits: struct {
+					Nodes []struct {
+						Commit struct {
+							Oid    githubql.String
+							Status struct {
+								Contexts []struct {
+									Context githubql.String
+									State   githubql.String
+								}
+							}
+						}
+					}
+				}{
+					Nodes: []struct {
+						Commit struct {
+							Oid    githubql.String
+							Status struct {
+								Contexts []struct {
+									Context githubql.String
+									State   githubql.String
+								}
+							}
+						}
+					}{
+						{
+							Commit: struct {
+								Oid    githubql.String
+								Status struct {
+									Contexts []struct {
+										Context githubql.String
+										State   githubql.String
+									}
+								}
+							}{
+								Oid: githubql.String(""),
+								Status: struct {
+									Contexts []struct {
+										Context githubql.String
+										State   githubql.String
+									}
+								}{
+									Contexts: []struct {
+										Context githubql.String
+										State   githubql.String
+									}{
+										{
+											Context: githubql.String(""),
+											State:   githubql.String(""),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:                    "submission for end-of-life version",
+			Labels:                  []string{"conformance-product-submission"},
+			KubernetesVersion:       common.Pointer("v1.28"),
+			KubernetesVersionLatest: common.Pointer("v1.31"),
+			SupportingFiles: []*suite.PullRequestFile{
+				{
+					Name:     "v1.28/coolkube/README.md",
+					BaseName: "README.md",
+					Contents: `# coolkube
+> the coolest Kubernetes distribution
+
+## Generating conformance results
+
+1. create a coolkube cluster
+2. sonobuoy run --wait && sonobuoy results "$(sonobuoy retrieve)" && sonobuoy delete --wait`,
+					BlobURL: "README.md",
+				},
+				{
+					Name:     "v1.28/coolkube/PRODUCT.yaml",
+					BaseName: "PRODUCT.yaml",
+					Contents: `vendor: "cool"
+name: "coolkube"
+version: "v1.28"
+type: "distribution"
+description: "it's just all-round cool and probably the best k8s, idk"
+website_url: "website_url"
+documentation_url: "docs"
+contact_email_address: "sales@coolkubernetes.com"`,
+					BlobURL: "PRODUCT.yaml",
+				},
+				{
+					Name:     "v1.28/coolkube/e2e.log",
+					BaseName: "e2e.log",
+					Contents: "",
+					BlobURL:  "e2e.log",
+				},
+				{
+					Name:     "v1.28/coolkube/junit_01.xml",
+					BaseName: "junit_01.xml",
+					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01xml,
+					BlobURL:  "junit_01.xml",
+				},
+			},
+			ExpectedComment: "Unable to use version v1.28 because it has reached end-of-life and is older than the last currently supported release v1.29.",
+			ExpectedError:   "unable to process release file as it is missing for release v1.28",
+			ExpectedLabels:  []string{"conformance-product-submission", "eol-version"},
+			PullRequestQuery: &suite.PullRequestQuery{
+				Title:  githubql.String("Conformance results for v1.28/coolkube"),
+				Number: githubql.Int(0),
+				Commits: struct {
+					Nodes []struct {
+						Commit struct {
+							Oid    githubql.String
+							Status struct {
+								Contexts []struct {
+									Context githubql.String
+									State   githubql.String
+								}
+							}
+						}
+					}
+				}{
+					Nodes: []struct {
+						Commit struct {
+							Oid    githubql.String
+							Status struct {
+								Contexts []struct {
+									Context githubql.String
+									State   githubql.String
+								}
+							}
+						}
+					}{
+						{
+							Commit: struct {
+								Oid    githubql.String
+								Status struct {
+									Contexts []struct {
+										Context githubql.String
+										State   githubql.String
+									}
+								}
+							}{
+								Oid: githubql.String(""),
+								Status: struct {
+									Contexts []struct {
+										Context githubql.String
+										State   githubql.String
+									}
+								}{
+									Contexts: []struct {
+										Context githubql.String
+										State   githubql.String
+									}{
+										{
+											Context: githubql.String(""),
+											State:   githubql.String(""),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "not a conformance pr",
+			PullRequestQuery: &suite.PullRequestQuery{
+				Title: githubql.String("soup recipes for winter"),
+			},
+			ExpectedComment: "This pull request appears to not be a conformance results submission; Checks will not run.",
+			ExpectedLabels:  []string{"not-conformance-product-submission", "unable-to-process"},
+			ExpectedStatus:  "pending",
+		},
+		{
+			Name: "submission spanning multiple versions",
+			PullRequestQuery: &suite.PullRequestQuery{
+				Title: githubql.String("Conformance results for v1.30/coolkube"),
+			},
+			SupportingFiles: []*suite.PullRequestFile{
+				{
+					Name:     "v1.29/coolkube/README.md",
+					BaseName: "README.md",
+					Contents: `# coolkube`,
+					BlobURL:  "README.md",
+				},
+				{
+					Name:     "v1.30/coolkube/README.md",
+					BaseName: "README.md",
+					Contents: `# coolkube`,
+					BlobURL:  "README.md",
+				},
+			},
+			ExpectedComment: "This pull request references more than one Kubernetes release version (v1.29, v1.30); a submission must target a single release matching its title.",
+			ExpectedLabels:  []string{"conformance-product-submission", "unable-to-process"},
+			ExpectedStatus:  "pending",
+		},
+		{
+			Name: "submission version directory conflicts with the title",
+			PullRequestQuery: &suite.PullRequestQuery{
+				Title: githubql.String("Conformance results for v1.30/coolkube"),
+			},
+			SupportingFiles: []*suite.PullRequestFile{
+				{
+					Name:     "v1.29/coolkube/README.md",
+					BaseName: "README.md",
+					Contents: `# coolkube`,
+					BlobURL:  "README.md",
+				},
+			},
+			ExpectedComment: "The files in this pull request are placed under the version directory v1.29, but the title declares version v1.30; Checks will not run until they match.",
+			ExpectedLabels:  []string{"conformance-product-submission", "unable-to-process"},
+			ExpectedStatus:  "pending",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			productYAML := map[string]string{}
+			var productYAMLSupportingFile string
+			for _, file := range tc.SupportingFiles {
+				if file.BaseName == "PRODUCT.yaml" {
+					productYAMLSupportingFile = file.Contents
+				}
+			}
+			if productYAMLSupportingFile != "" {
+				if err := yaml.Unmarshal([]byte(productYAMLSupportingFile), &productYAML); err != nil {
+					t.Fatalf("error: unmarshalling from PRODUCT.yaml supporting file: %v", err)
+				}
+			}
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Logf("requesting path '%v'", r.URL.Path)
+				supportingFile := &suite.PullRequestFile{}
+				for _, file := range tc.SupportingFiles {
+					if r.URL.Path == "/"+file.BaseName || r.URL.Path == "/"+file.Name {
+						supportingFile = file
+					}
+				}
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(supportingFile.Contents))
+				if err != nil {
+					t.Fatalf("error: sending http response; %v", err)
+				}
+			}))
+			defer svr.Close()
+			for _, field := range []string{"website_url", "documentation_url"} {
+				if productYAML[field] != "" {
+					productYAML[field] = svr.URL + "/" + productYAML[field]
+				}
+			}
+			productYAMLBytes, err := yaml.Marshal(productYAML)
+			if err != nil {
+				t.Fatalf("error: marshalling new product yaml: %v", err)
+			}
+			for i := range tc.SupportingFiles {
+				tc.SupportingFiles[i].BlobURL = svr.URL + "/" + tc.SupportingFiles[i].BlobURL
+				if tc.SupportingFiles[i].BaseName == "PRODUCT.yaml" {
+					tc.SupportingFiles[i].Contents = string(productYAMLBytes)
+				}
+			}
+			ghc := NewFakeGitHubClient([]*prContext{
+				{
+					PullRequestQuery: tc.PullRequestQuery,
+					SupportingFiles:  tc.SupportingFiles,
+				},
+			})
+			if err := handle(context.TODO(), log, ghc, tc.PullRequestQuery); err != nil && !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.ExpectedComment != "" {
+				found := false
+				got := ""
+				for _, comment := range ghc.PopulatedPullRequests[tc.PullRequestQuery.Number].Comments {
+					if strings.Contains(comment.Body, tc.ExpectedComment) {
+						got = comment.Body
+						found = true
+					}
+				}
+				if !found {
+					t.Fatalf("unexpected comment: want = %v; got %v", tc.ExpectedComment, got)
+				}
+			}
+			if want, got := tc.ExpectedStatus, ghc.PopulatedPullRequests[tc.PullRequestQuery.Number].Status.State; want != "" && want != got {
+				t.Fatalf("unexpected status: want = %v; got = %v", want, got)
+			}
+			if want := tc.ExpectedFirstStatus; want != "" {
+				statuses := ghc.PopulatedPullRequests[tc.PullRequestQuery.Number].Statuses
+				if len(statuses) == 0 || statuses[0].State != want {
+					t.Fatalf("unexpected first status: want = %v; got = %+v", want, statuses)
+				}
+			}
+			prLabels := []string{}
+			for _, l := range ghc.PopulatedPullRequests[tc.PullRequestQuery.Number].PullRequestQuery.Labels.Nodes {
+				prLabels = append(prLabels, string(l.Name))
+			}
+			if !reflect.DeepEqual(tc.ExpectedLabels, prLabels) {
+				t.Fatalf("unexpected labels: want = %v; got = %v", tc.ExpectedLabels, prLabels)
+			}
+		})
+	}
+}
+
+func Test_annotationForFailure(t *testing.T) {
+	prSuite := suite.NewPRSuite(&suite.PullRequest{
+		SupportingFiles: []*suite.PullRequestFile{
+			{
+				Name:     "v1.30/coolkube/PRODUCT.yaml",
+				BaseName: "PRODUCT.yaml",
+			},
+			{
+				Name:     "v1.30/coolkube/junit_01.xml",
+				BaseName: "junit_01.xml",
+			},
+		},
+	})
+	type testCase struct {
+		Name         string
+		Failure      suite.ResultPrepare
+		ExpectedPath string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name: "failure mentioning a file is annotated on that file",
+			Failure: suite.ResultPrepare{
+				Name:  "all required tests pass",
+				Hints: []string{"unable to find file junit_01.xml"},
+			},
+			ExpectedPath: "v1.30/coolkube/junit_01.xml",
+		},
+		{
+			Name: "failure without a file defaults to PRODUCT.yaml",
+			Failure: suite.ResultPrepare{
+				Name: "the submission has a valid title",
+			},
+			ExpectedPath: "v1.30/coolkube/PRODUCT.yaml",
+		},
+	} {
+		annotation := annotationForFailure(prSuite, tc.Failure)
+		if string(annotation.Path) != tc.ExpectedPath {
+			t.Fatalf("error: testcase (%v) annotation path (%v) doesn't match expected path (%v)", tc.Name, annotation.Path, tc.ExpectedPath)
+		}
+		if annotation.AnnotationLevel != githubql.CheckAnnotationLevelFailure {
+			t.Fatalf("error: testcase (%v) annotation level (%v) isn't failure", tc.Name, annotation.AnnotationLevel)
+		}
+		if !strings.Contains(string(annotation.Message), tc.Failure.Name) {
+			t.Fatalf("error: testcase (%v) annotation message (%v) doesn't mention the failed requirement", tc.Name, annotation.Message)
+		}
+	}
+}
+
+func TestHandleChecksRun(t *testing.T) {
+	if err := os.Setenv("KO_DATA_PATH", "./../../kodata"); err != nil {
+		log.Fatalf("failed to set env: %v", err)
+	}
+	SetChecksRunEnabled(true)
+	defer SetChecksRunEnabled(false)
+
+	newSupportingFiles := func(svrURL string) []*suite.PullRequestFile {
+		return []*suite.PullRequestFile{
+			{
+				Name:     "v1.30/coolkube/README.md",
+				BaseName: "README.md",
+				Contents: `# coolkube`,
+				BlobURL:  svrURL + "/README.md",
+			},
+			{
+				Name:     "v1.30/coolkube/PRODUCT.yaml",
+				BaseName: "PRODUCT.yaml",
+				Contents: `vendor: "cool"
+name: "coolkube"
+version: "v1.30"
+type: "distribution"
+description: "it's just all-round cool and probably the best k8s, idk"
+website_url: "https://coolkubernetes.com"
+documentation_url: "https://coolkubernetes.com/docs"
+contact_email_address: "sales@coolkubernetes.com"`,
+				BlobURL: svrURL + "/PRODUCT.yaml",
+			},
+			{
+				Name:     "v1.30/coolkube/e2e.log",
+				BaseName: "e2e.log",
+				Contents: "12345",
+				BlobURL:  svrURL + "/e2e.log",
+			},
+		}
+	}
+
+	type testCase struct {
+		Name                 string
+		FailCheckRunCreation bool
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name: "results are reported as a checks run",
+		},
+		{
+			Name:                 "falls back to a commit status when the checks run fails",
+			FailCheckRunCreation: true,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			var supportingFiles []*suite.PullRequestFile
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for _, file := range supportingFiles {
+					if r.URL.Path == "/"+file.BaseName {
+						w.WriteHeader(http.StatusOK)
+						if _, err := w.Write([]byte(file.Contents)); err != nil {
+							t.Errorf("error: sending http response; %v", err)
+						}
+						return
+					}
+				}
+				w.WriteHeader(http.StatusNotFound)
+			}))
+			defer svr.Close()
+			supportingFiles = newSupportingFiles(svr.URL)
+			// junit_01.xml is intentionally missing, so some requirements fail
+			pullRequestQuery := &suite.PullRequestQuery{
+				Title:  githubql.String("Conformance results for v1.30/coolkube"),
+				Number: githubql.Int(0),
+			}
+			ghc := NewFakeGitHubClient([]*prContext{
+				{
+					PullRequestQuery: pullRequestQuery,
+					SupportingFiles:  supportingFiles,
+				},
+			})
+			ghc.FailCheckRunCreation = tc.FailCheckRunCreation
+			if err := handle(context.TODO(), log, ghc, pullRequestQuery); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.FailCheckRunCreation {
+				if len(ghc.CreatedCheckRuns) != 0 {
+					t.Fatalf("error: expected no checks run to be created; got %v", len(ghc.CreatedCheckRuns))
+				}
+				if state := ghc.PopulatedPullRequests[0].Status.State; state != "failure" {
+					t.Fatalf("error: expected a fallback commit status of failure; got '%v'", state)
+				}
+				return
+			}
+			if len(ghc.CreatedCheckRuns) != 1 {
+				t.Fatalf("error: expected one checks run to be created; got %v", len(ghc.CreatedCheckRuns))
+			}
+			checkRun := ghc.CreatedCheckRuns[0]
+			if checkRun.Conclusion == nil || *checkRun.Conclusion != githubql.CheckConclusionStateFailure {
+				t.Fatalf("error: expected a checks run conclusion of failure; got %+v", checkRun.Conclusion)
+			}
+			if checkRun.Output == nil || checkRun.Output.Annotations == nil || len(*checkRun.Output.Annotations) == 0 {
+				t.Fatalf("error: expected annotations for the failed requirements")
+			}
+		})
+	}
+}
+
+func TestHandleDryRun(t *testing.T) {
+	if err := os.Setenv("KO_DATA_PATH", "./../../kodata"); err != nil {
+		log.Fatalf("failed to set env: %v", err)
+	}
+	SetDryRun(true)
+	defer SetDryRun(false)
+
+	var supportingFiles []*suite.PullRequestFile
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, file := range supportingFiles {
+			if r.URL.Path == "/"+file.BaseName {
+				w.WriteHeader(http.StatusOK)
+				if _, err := w.Write([]byte(file.Contents)); err != nil {
+					t.Errorf("error: sending http response; %v", err)
+				}
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer svr.Close()
+	supportingFiles = []*suite.PullRequestFile{
+		{
+			Name:     "v1.30/coolkube/README.md",
+			BaseName: "README.md",
+			Contents: `# coolkube`,
+			BlobURL:  svr.URL + "/README.md",
+		},
+		{
+			Name:     "v1.30/coolkube/PRODUCT.yaml",
+			BaseName: "PRODUCT.yaml",
+			Contents: `vendor: "cool"
+name: "coolkube"
+version: "v1.30"`,
+			BlobURL: svr.URL + "/PRODUCT.yaml",
+		},
+	}
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Number: githubql.Int(0),
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles:  supportingFiles,
+		},
+	})
+	if err := handle(context.TODO(), log, ghc, pullRequestQuery); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if comments := ghc.PopulatedPullRequests[0].Comments; len(comments) != 0 {
+		t.Fatalf("error: expected no comments to be created in dry-run mode; got %v", comments)
+	}
+	if statuses := ghc.PopulatedPullRequests[0].Statuses; len(statuses) != 0 {
+		t.Fatalf("error: expected no statuses to be created in dry-run mode; got %v", statuses)
+	}
+	if labels := ghc.PopulatedPullRequests[0].PullRequestQuery.Labels.Nodes; len(labels) != 0 {
+		t.Fatalf("error: expected no labels to be added in dry-run mode; got %v", labels)
+	}
+}
+
+func TestHandlerDirectConstruction(t *testing.T) {
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Number: githubql.Int(0),
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles:  []*suite.PullRequestFile{},
+		},
+	})
+	handler := NewHandler(ghc)
+	handler.FeaturePaths = []string{"./../../kodata/features/"}
+	handler.MetadataFolder = "./../../kodata/conformance-testdata"
+	handler.HTTPClient = http.DefaultClient
+	if err := handler.Handle(context.TODO(), pullRequestQuery); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	comments := ghc.PopulatedPullRequests[0].Comments
+	if len(comments) != 1 {
+		t.Fatalf("error: expected one comment to be created; got %v", len(comments))
+	}
+	if !strings.Contains(comments[0].Body, "changes no files") {
+		t.Fatalf("error: unexpected comment body '%v'", comments[0].Body)
+	}
+}
+
+func TestHandleRequiredAcknowledgment(t *testing.T) {
+	defaultRequiredAcknowledgment := requiredAcknowledgment
+	defer func() {
+		requiredAcknowledgment = defaultRequiredAcknowledgment
+	}()
+	SetRequiredAcknowledgment("I agree to the Certified Kubernetes Terms")
+
+	// a PR whose description is missing the phrase is flagged and checks
+	// don't run
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Body:   githubql.String("Here are our conformance results."),
+		Number: githubql.Int(0),
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles:  []*suite.PullRequestFile{},
+		},
+	})
+	if err := handle(context.TODO(), log, ghc, pullRequestQuery); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	comments := ghc.PopulatedPullRequests[0].Comments
+	if len(comments) != 1 {
+		t.Fatalf("error: expected one comment to be created; got %v", len(comments))
+	}
+	if !strings.Contains(comments[0].Body, "missing the required acknowledgment") ||
+		!strings.Contains(comments[0].Body, "> I agree to the Certified Kubernetes Terms") {
+		t.Fatalf("error: unexpected comment body '%v'", comments[0].Body)
+	}
+	foundAckLabel := false
+	for _, l := range ghc.PopulatedPullRequests[0].PullRequestQuery.Labels.Nodes {
+		if string(l.Name) == "acknowledgment-missing" {
+			foundAckLabel = true
+		}
+	}
+	if !foundAckLabel {
+		t.Fatalf("error: expected the acknowledgment-missing label to be added")
+	}
+
+	// the phrase is matched case-insensitively and the checks continue
+	pullRequestQuery = &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Body:   githubql.String("i AGREE to the certified kubernetes terms."),
+		Number: githubql.Int(0),
+	}
+	ghc = NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles:  []*suite.PullRequestFile{},
+		},
+	})
+	if err := handle(context.TODO(), log, ghc, pullRequestQuery); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	comments = ghc.PopulatedPullRequests[0].Comments
+	if len(comments) != 1 {
+		t.Fatalf("error: expected one comment to be created; got %v", len(comments))
+	}
+	if !strings.Contains(comments[0].Body, "changes no files") {
+		t.Fatalf("error: unexpected comment body '%v'", comments[0].Body)
+	}
+	for _, l := range ghc.PopulatedPullRequests[0].PullRequestQuery.Labels.Nodes {
+		if string(l.Name) == "acknowledgment-missing" {
+			t.Fatalf("error: the acknowledgment-missing label must not be added when the phrase is present")
+		}
+	}
+}
+
+func TestHandlePRSuiteErrorMapping(t *testing.T) {
+	originalBackoff := fetchFileRetryBackoff
+	fetchFileRetryBackoff = time.Millisecond
+	defer func() {
+		fetchFileRetryBackoff = originalBackoff
+	}()
+
+	// a content problem, such as a corrupt gzip file, results in a
+	// submitter-facing comment rather than a handler error
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte("not actually gzip data")); err != nil {
+			t.Errorf("error: sending http response; %v", err)
+		}
+	}))
+	defer svr.Close()
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Number: githubql.Int(0),
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles: []*suite.PullRequestFile{
+				{
+					Name:     "v1.30/coolkube/e2e.log.gz",
+					BaseName: "e2e.log.gz",
+					BlobURL:  svr.URL + "/e2e.log.gz",
+				},
+			},
+		},
+	})
+	if err := handle(context.TODO(), log, ghc, pullRequestQuery); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	comments := ghc.PopulatedPullRequests[0].Comments
+	if len(comments) != 1 {
+		t.Fatalf("error: expected one comment to be created; got %v", len(comments))
+	}
+	if !strings.Contains(comments[0].Body, "There was a problem processing a file in this submission") ||
+		!strings.Contains(comments[0].Body, "error decompressing content") {
+		t.Fatalf("error: unexpected comment body '%v'", comments[0].Body)
+	}
+
+	// a transient fetch failure is returned to the caller for a retry, with
+	// the status left pending and no comment posted
+	pullRequestQuery = &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Number: githubql.Int(0),
+	}
+	fake := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles:  []*suite.PullRequestFile{},
+		},
+	})
+	err := handle(context.TODO(), log, &failingChangesFakeGitHubClient{fake}, pullRequestQuery)
+	if err == nil {
+		t.Fatalf("error: expected a transient fetch failure to be returned")
+	}
+	if category := prSuiteErrorCategory(err); category != PRSuiteErrorTransient {
+		t.Fatalf("error: category (%v) doesn't match what is expected (%v)", category, PRSuiteErrorTransient)
+	}
+	if len(fake.PopulatedPullRequests[0].Comments) != 0 {
+		t.Fatalf("error: expected no comments to be created; got %v", len(fake.PopulatedPullRequests[0].Comments))
+	}
+	if fake.PopulatedPullRequests[0].Status.State != "pending" {
+		t.Fatalf("error: expected a pending status; got '%v'", fake.PopulatedPullRequests[0].Status.State)
+	}
+}
+
+func TestHandleNonconformingTitleWithProductYAML(t *testing.T) {
+	if err := os.Setenv("KO_DATA_PATH", "./../../kodata"); err != nil {
+		log.Fatalf("failed to set env: %v", err)
+	}
+	var supportingFiles []*suite.PullRequestFile
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, file := range supportingFiles {
+			if r.URL.Path == "/"+file.BaseName {
+				w.WriteHeader(http.StatusOK)
+				if _, err := w.Write([]byte(file.Contents)); err != nil {
+					t.Errorf("error: sending http response; %v", err)
+				}
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+	supportingFiles = []*suite.PullRequestFile{
+		{
+			Name:     "v1.30/coolkube/PRODUCT.yaml",
+			BaseName: "PRODUCT.yaml",
+			Contents: fmt.Sprintf(`vendor: "cool"
+name: "coolkube"
+version: "v1.30"
+type: "distribution"
+description: "it's just all-round cool and probably the best k8s, idk"
+website_url: "%v/website"
+documentation_url: "%v/docs"
+contact_email_address: "sales@coolkubernetes.com"`, svr.URL, svr.URL),
+			BlobURL: svr.URL + "/PRODUCT.yaml",
+		},
+	}
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:  githubql.String("coolkube certification run"),
+		Number: githubql.Int(0),
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles:  supportingFiles,
+		},
+	})
+	if err := handle(context.TODO(), log, ghc, pullRequestQuery); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	foundAdvisory := false
+	for _, comment := range ghc.PopulatedPullRequests[0].Comments {
+		if strings.Contains(comment.Body, "appears to not be a conformance results submission") {
+			t.Fatalf("error: a PRODUCT.yaml-bearing PR must not be rejected for its title; got comment '%v'", comment.Body)
+		}
+		if strings.Contains(comment.Body, "Note: the pull request title doesn't match the expected format") {
+			foundAdvisory = true
+		}
+	}
+	if !foundAdvisory {
+		t.Fatalf("error: expected an advisory about the nonconforming title")
+	}
+	for _, l := range ghc.PopulatedPullRequests[0].PullRequestQuery.Labels.Nodes {
+		if string(l.Name) == "not-conformance-product-submission" {
+			t.Fatalf("error: the not-conformance-product-submission label must not be added")
+		}
+	}
+}
+
+func TestHandleWrongBaseBranch(t *testing.T) {
+	if err := os.Setenv("KO_DATA_PATH", "./../../kodata"); err != nil {
+		log.Fatalf("failed to set env: %v", err)
+	}
+	SetExpectedBaseBranch("master")
+	defer SetExpectedBaseBranch("")
+	var supportingFiles []*suite.PullRequestFile
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, file := range supportingFiles {
+			if r.URL.Path == "/"+file.BaseName {
+				w.WriteHeader(http.StatusOK)
+				if _, err := w.Write([]byte(file.Contents)); err != nil {
+					t.Errorf("error: sending http response; %v", err)
+				}
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+	supportingFiles = []*suite.PullRequestFile{
+		{
+			Name:     "v1.30/coolkube/PRODUCT.yaml",
+			BaseName: "PRODUCT.yaml",
+			Contents: fmt.Sprintf(`vendor: "cool"
+name: "coolkube"
+version: "v1.30"
+type: "distribution"
+description: "it's just all-round cool and probably the best k8s, idk"
+website_url: "%v/website"
+documentation_url: "%v/docs"
+contact_email_address: "sales@coolkubernetes.com"`, svr.URL, svr.URL),
+			BlobURL: svr.URL + "/PRODUCT.yaml",
+		},
+	}
+	type testCase struct {
+		Name           string
+		BaseRefName    string
+		ExpectAdvisory bool
+	}
+	for _, tc := range []testCase{
+		{
+			Name:           "targets the wrong base branch",
+			BaseRefName:    "release-1.30",
+			ExpectAdvisory: true,
+		},
+		{
+			Name:           "targets the expected base branch",
+			BaseRefName:    "master",
+			ExpectAdvisory: false,
+		},
+	} {
+		pullRequestQuery := &suite.PullRequestQuery{
+			Title:  githubql.String("Conformance results for v1.30/coolkube"),
+			Number: githubql.Int(0),
+			BaseRef: struct {
+				Name githubql.String
+			}{
+				Name: githubql.String(tc.BaseRefName),
+			},
+		}
+		ghc := NewFakeGitHubClient([]*prContext{
+			{
+				PullRequestQuery: pullRequestQuery,
+				SupportingFiles:  supportingFiles,
+			},
+		})
+		if err := handle(context.TODO(), log, ghc, pullRequestQuery); err != nil {
+			t.Fatalf("error: with test case '%v', %v", tc.Name, err)
+		}
+		foundAdvisory := false
+		for _, comment := range ghc.PopulatedPullRequests[0].Comments {
+			if strings.Contains(comment.Body, "rather than the expected 'master'") {
+				foundAdvisory = true
+			}
+		}
+		if foundAdvisory != tc.ExpectAdvisory {
+			t.Fatalf("error: with test case '%v', expected advisory %v but found %v", tc.Name, tc.ExpectAdvisory, foundAdvisory)
+		}
+		foundLabel := false
+		for _, l := range ghc.PopulatedPullRequests[0].PullRequestQuery.Labels.Nodes {
+			if string(l.Name) == "wrong-base-branch" {
+				foundLabel = true
+			}
+		}
+		if foundLabel != tc.ExpectAdvisory {
+			t.Fatalf("error: with test case '%v', expected wrong-base-branch label %v but found %v", tc.Name, tc.ExpectAdvisory, foundLabel)
+		}
+	}
+}
+
+func TestHandleSuccessComment(t *testing.T) {
+	if err := os.Setenv("KO_DATA_PATH", "./../../kodata"); err != nil {
+		log.Fatalf("failed to set env: %v", err)
+	}
+	successMessage := "Congratulations! A reviewer will be assigned to merge this submission shortly."
+	SetSuccessComment(successMessage)
+	defer SetSuccessComment("")
+
+	var supportingFiles []*suite.PullRequestFile
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, file := range supportingFiles {
+			if r.URL.Path == "/"+file.BaseName {
+				w.WriteHeader(http.StatusOK)
+				if _, err := w.Write([]byte(file.Contents)); err != nil {
+					t.Errorf("error: sending http response; %v", err)
+				}
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+	supportingFiles = []*suite.PullRequestFile{
+		{
+			Name:     "v1.30/coolkube/README.md",
+			BaseName: "README.md",
+			Contents: `# coolkube
+> the coolest Kubernetes distribution
+
+## Generating conformance results
+
+1. create a coolkube cluster
+2. sonobuoy run --wait && sonobuoy results "$(sonobuoy retrieve)" && sonobuoy delete --wait`,
+			BlobURL: svr.URL + "/README.md",
+		},
+		{
+			Name:     "v1.30/coolkube/PRODUCT.yaml",
+			BaseName: "PRODUCT.yaml",
+			Contents: fmt.Sprintf(`vendor: "cool"
+name: "coolkube"
+version: "v1.30"
+type: "distribution"
+description: "it's just all-round cool and probably the best k8s, idk"
+website_url: "%v/website"
+documentation_url: "%v/docs"
+contact_email_address: "sales@coolkubernetes.com"`, svr.URL, svr.URL),
+			BlobURL: svr.URL + "/PRODUCT.yaml",
+		},
+		{
+			Name:     "v1.30/coolkube/e2e.log",
+			BaseName: "e2e.log",
+			Contents: "Ran 2 of 2 Specs in 10.0 seconds\nSUCCESS! -- 2 Passed | 0 Failed | 0 Pending | 0 Skipped",
+			BlobURL:  svr.URL + "/e2e.log",
+		},
+		{
+			Name:     "v1.30/coolkube/junit_01.xml",
+			BaseName: "junit_01.xml",
+			Contents: testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01xml,
+			BlobURL:  svr.URL + "/junit_01.xml",
+		},
+	}
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Number: githubql.Int(0),
+		Commits: struct {
+			Nodes []struct {
+				Commit struct {
+					Oid    githubql.String
+					Status struct {
+						Contexts []struct {
+							Context githubql.String
+							State   githubql.String
+						}
+					}
+				}
+			}
+		}{
+			Nodes: []struct {
+				Commit struct {
+					Oid    githubql.String
+					Status struct {
+						Contexts []struct {
+							Context githubql.String
+							State   githubql.String
+						}
+					}
+				}
+			}{
+				{
+					Commit: struct {
+						Oid    githubql.String
+						Status struct {
+							Contexts []struct {
+								Context githubql.String
+								State   githubql.String
+							}
+						}
+					}{
+						Oid: githubql.String(""),
+						Status: struct {
+							Contexts []struct {
+								Context githubql.String
+								State   githubql.String
+							}
+						}{
+							Contexts: []struct {
+								Context githubql.String
+								State   githubql.String
+							}{
+								{
+									Context: githubql.String(""),
+									State:   githubql.String(""),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	ghc := &botAwareFakeGitHubClient{NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles:  supportingFiles,
+		},
+	})}
+	// handle the PR twice, as the periodic scan would; the success message
+	// must only be posted on the first, passing tick
+	for i := 0; i < 2; i++ {
+		if err := handle(context.TODO(), log, ghc, pullRequestQuery); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	successCommentCount := 0
+	for _, comment := range ghc.PopulatedPullRequests[0].Comments {
+		if strings.Contains(comment.Body, successMessage) {
+			successCommentCount++
+		}
+	}
+	if successCommentCount != 1 {
+		t.Fatalf("error: expected the success comment to appear exactly once; found it %v time(s) in %v comment(s)", successCommentCount, len(ghc.PopulatedPullRequests[0].Comments))
+	}
+}
+
+func TestHandleSkipsVerifiedHead(t *testing.T) {
+	if err := os.Setenv("KO_DATA_PATH", "./../../kodata"); err != nil {
+		log.Fatalf("failed to set env: %v", err)
+	}
+	var supportingFiles []*suite.PullRequestFile
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, file := range supportingFiles {
+			if r.URL.Path == "/"+file.BaseName {
+				w.WriteHeader(http.StatusOK)
+				if _, err := w.Write([]byte(file.Contents)); err != nil {
+					t.Errorf("error: sending http response; %v", err)
+				}
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+	supportingFiles = []*suite.PullRequestFile{
+		{
+			Name:     "v1.30/coolkube/README.md",
+			BaseName: "README.md",
+			Contents: `# coolkube
+> the coolest Kubernetes distribution
+
+## Generating conformance results
+
+1. create a coolkube cluster
+2. sonobuoy run --wait && sonobuoy results "$(sonobuoy retrieve)" && sonobuoy delete --wait`,
+			BlobURL: svr.URL + "/README.md",
+		},
+		{
+			Name:     "v1.30/coolkube/PRODUCT.yaml",
+			BaseName: "PRODUCT.yaml",
+			Contents: fmt.Sprintf(`vendor: "cool"
+name: "coolkube"
+version: "v1.30"
+type: "distribution"
+description: "it's just all-round cool and probably the best k8s, idk"
+website_url: "%v/website"
+documentation_url: "%v/docs"
+contact_email_address: "sales@coolkubernetes.com"`, svr.URL, svr.URL),
+			BlobURL: svr.URL + "/PRODUCT.yaml",
+		},
+		{
+			Name:     "v1.30/coolkube/e2e.log",
+			BaseName: "e2e.log",
+			Contents: "Ran 2 of 2 Specs in 10.0 seconds\nSUCCESS! -- 2 Passed | 0 Failed | 0 Pending | 0 Skipped",
+			BlobURL:  svr.URL + "/e2e.log",
+		},
+		{
+			Name:     "v1.30/coolkube/junit_01.xml",
+			BaseName: "junit_01.xml",
+			Contents: testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01xml,
+			BlobURL:  svr.URL + "/junit_01.xml",
+		},
+	}
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:      githubql.String("Conformance results for v1.30/coolkube"),
+		Number:     githubql.Int(77),
+		HeadRefOID: githubql.String("abc123"),
+		Commits: struct {
+			Nodes []struct {
+				Commit struct {
+					Oid    githubql.String
+					Status struct {
+						Contexts []struct {
+							Context githubql.String
+							State   githubql.String
+						}
+					}
+				}
+			}
+		}{
+			Nodes: []struct {
+				Commit struct {
+					Oid    githubql.String
+					Status struct {
+						Contexts []struct {
+							Context githubql.String
+							State   githubql.String
+						}
+					}
+				}
+			}{
+				{
+					Commit: struct {
+						Oid    githubql.String
+						Status struct {
+							Contexts []struct {
+								Context githubql.String
+								State   githubql.String
+							}
+						}
+					}{
+						Oid: githubql.String("abc123"),
+						Status: struct {
+							Contexts []struct {
+								Context githubql.String
+								State   githubql.String
+							}
+						}{
+							Contexts: []struct {
+								Context githubql.String
+								State   githubql.String
+							}{
+								{
+									Context: githubql.String(""),
+									State:   githubql.String(""),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	defer invalidateComputedResults(pullRequestQuery)
+	ghc := &botAwareFakeGitHubClient{NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles:  supportingFiles,
+		},
+	})}
+	if err := handle(context.TODO(), log, ghc, pullRequestQuery); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if len(ghc.PullRequestChangesCalls) != 1 {
+		t.Fatalf("error: expected the first tick to fetch the PR changes once; got %v fetch(es)", len(ghc.PullRequestChangesCalls))
+	}
+	// an unchanged head is reconciled on the next tick without re-fetching
+	// artifacts or re-running the suite
+	if err := handle(context.TODO(), log, ghc, pullRequestQuery); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if len(ghc.PullRequestChangesCalls) != 1 {
+		t.Fatalf("error: expected the unchanged head not to be re-run; got %v fetch(es)", len(ghc.PullRequestChangesCalls))
+	}
+	// a requested recheck invalidates the recorded result and runs in full
+	invalidateComputedResults(pullRequestQuery)
+	if err := handle(context.TODO(), log, ghc, pullRequestQuery); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if len(ghc.PullRequestChangesCalls) != 2 {
+		t.Fatalf("error: expected the invalidated head to be re-run; got %v fetch(es)", len(ghc.PullRequestChangesCalls))
+	}
+}
+
+func TestHandleLogsStructuredFields(t *testing.T) {
+	if err := os.Setenv("KO_DATA_PATH", "./../../kodata"); err != nil {
+		log.Fatalf("failed to set env: %v", err)
+	}
+	var logBuffer bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&logBuffer)
+	logger.SetFormatter(&logrus.JSONFormatter{})
+	entry := logger.WithField("plugin", "verify-conformance")
+
+	var supportingFiles []*suite.PullRequestFile
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, file := range supportingFiles {
+			if r.URL.Path == "/"+file.BaseName {
+				w.WriteHeader(http.StatusOK)
+				if _, err := w.Write([]byte(file.Contents)); err != nil {
+					t.Errorf("error: sending http response; %v", err)
+				}
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer svr.Close()
+	supportingFiles = []*suite.PullRequestFile{
+		{
+			Name:     "v1.30/coolkube/README.md",
+			BaseName: "README.md",
+			Contents: `# coolkube`,
+			BlobURL:  svr.URL + "/README.md",
+		},
+		{
+			Name:     "v1.30/coolkube/PRODUCT.yaml",
+			BaseName: "PRODUCT.yaml",
+			Contents: `vendor: "cool"
+name: "coolkube"
+version: "v1.30"`,
+			BlobURL: svr.URL + "/PRODUCT.yaml",
+		},
+	}
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Number: githubql.Int(0),
+		Repository: struct {
+			Name  githubql.String
+			Owner struct{ Login githubql.String }
+		}{
+			Name: githubql.String("k8s-conformance"),
+			Owner: struct{ Login githubql.String }{
+				Login: githubql.String("cncf"),
+			},
+		},
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles:  supportingFiles,
+		},
+	})
+	if err := handle(context.TODO(), entry, ghc, pullRequestQuery); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, field := range []string{`"org":"cncf"`, `"repo":"k8s-conformance"`, `"pr":0`, `"version":"v1.30"`} {
+		if !strings.Contains(logBuffer.String(), field) {
+			t.Fatalf("error: log output doesn't contain the field %v", field)
+		}
+	}
+}
+
+func TestNewPullRequestQueryForGithubPullRequest(t *testing.T) {
+	prq, err := NewPullRequestQueryForGithubPullRequest(
+		"cncf",
+		"k8s-conformance",
+		0,
+		&github.PullRequest{
+			User: github.User{
+				Login: "cncf-ci",
+			},
+			Body: "I acknowledge the conformance submission guidelines.",
+			Base: github.PullRequestBranch{
+				Ref: "master",
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if prq == nil {
+		t.Fatalf("PullRequestQuery must never be empty")
+	}
+	// the body and base branch feed the acknowledgment and base-branch
+	// checks, which would otherwise misfire on event-triggered runs
+	if want, got := "I acknowledge the conformance submission guidelines.", string(prq.Body); want != got {
+		t.Fatalf("error: unexpected body: want = %v; got = %v", want, got)
+	}
+	if want, got := "master", string(prq.BaseRef.Name); want != got {
+		t.Fatalf("error: unexpected base ref: want = %v; got = %v", want, got)
+	}
+	// a fork PR is authored by a user outside the org; the repository owner
+	// must stay the org so labels and statuses address the right repo
+	if want, got := "cncf", string(prq.Repository.Owner.Login); want != got {
+		t.Fatalf("error: unexpected repository owner: want = %v; got = %v", want, got)
+	}
+	if want, got := "k8s-conformance", string(prq.Repository.Name); want != got {
+		t.Fatalf("error: unexpected repository name: want = %v; got = %v", want, got)
+	}
+	if want, got := "cncf-ci", string(prq.Author.Login); want != got {
+		t.Fatalf("error: unexpected author: want = %v; got = %v", want, got)
+	}
+	if _, err := NewPullRequestQueryForGithubPullRequest("cncf", "k8s-conformance", 1, nil); err == nil {
+		t.Fatalf("error: expected an error for an event with no pull request")
+	}
+	if _, err := NewPullRequestQueryForGithubPullRequest("", "", 1, &github.PullRequest{}); err == nil {
+		t.Fatalf("error: expected an error for an event naming no repository")
+	}
+}
+
+func TestHandlePullRequestEvent(t *testing.T) {
+	type args struct {
+		log *logrus.Entry
+		pre *github.PullRequestEvent
+	}
+	tests := []struct {
+		name            string
+		supportingFiles []*suite.PullRequestFile
+		args            args
+		wantErr         bool
+	}{
+		{
+			name: "basic",
+			supportingFiles: []*suite.PullRequestFile{
+				{
+					Name:     "v1.30/coolkube/README.md",
+					BaseName: "README.md",
+					Contents: `# coolkube
+> the coolest Kubernetes distribution
+
+## Generating conformance results
+
+1. create a coolkube cluster
+2. sonobuoy run --wait && sonobuoy results "$(sonobuoy retrieve)" && sonobuoy delete --wait`,
+					BlobURL: "README.md",
+				},
+				{
+					Name:     "v1.30/coolkube/PRODUCT.yaml",
+					BaseName: "PRODUCT.yaml",
+					Contents: `vendor: "cool"
+name: "coolkube"
+version: "v1.30"
+type: "distribution"
+description: "it's just all-round cool and probably the best k8s, idk"
+website_url: "website_url"
+documentation_url: "docs"
+contact_email_address: "sales@coolkubernetes.com"`,
+					BlobURL: "PRODUCT.yaml",
+				},
+				{
+					Name:     "v1.30/coolkube/e2e.log",
+					BaseName: "e2e.log",
+					Contents: "",
+					BlobURL:  "e2e.log",
+				},
+				{
+					Name:     "v1.30/coolkube/junit_01.xml",
+					BaseName: "junit_01.xml",
+					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01xml,
+					BlobURL:  "junit_01.xml",
+				},
+			},
+			args: args{
+				log: log,
+				pre: &github.PullRequestEvent{
+					Action: github.PullRequestActionOpened,
+					Repo: github.Repo{
+						Owner: github.User{
+							Login: "cncf",
+						},
+						Name: "k8s-conformance",
+					},
+					Number: 12345,
+					PullRequest: github.PullRequest{
+						Title: "Conformance results for v1.27/coolkube",
+						User: github.User{
+							Login: "example",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "synchronize re-runs checks on a new head commit",
+			supportingFiles: []*suite.PullRequestFile{
+				{
+					Name:     "v1.30/coolkube/README.md",
+					BaseName: "README.md",
+					Contents: `# coolkube
+> the coolest Kubernetes distribution
+
+## Generating conformance results
+
+1. create a coolkube cluster
+2. sonobuoy run --wait && sonobuoy results "$(sonobuoy retrieve)" && sonobuoy delete --wait`,
+					BlobURL: "README.md",
+				},
+				{
+					Name:     "v1.30/coolkube/PRODUCT.yaml",
+					BaseName: "PRODUCT.yaml",
+					Contents: `vendor: "cool"
+name: "coolkube"
+version: "v1.30"
+type: "distribution"
+description: "it's just all-round cool and probably the best k8s, idk"
+website_url: "website_url"
+documentation_url: "docs"
+contact_email_address: "sales@coolkubernetes.com"`,
+					BlobURL: "PRODUCT.yaml",
+				},
+				{
+					Name:     "v1.30/coolkube/e2e.log",
+					BaseName: "e2e.log",
+					Contents: "",
+					BlobURL:  "e2e.log",
+				},
+				{
+					Name:     "v1.30/coolkube/junit_01.xml",
+					BaseName: "junit_01.xml",
+					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01xml,
+					BlobURL:  "junit_01.xml",
+				},
+			},
+			args: args{
+				log: log,
+				pre: &github.PullRequestEvent{
+					Action: github.PullRequestActionSynchronize,
+					Repo: github.Repo{
+						Owner: github.User{
+							Login: "cncf",
+						},
+						Name: "k8s-conformance",
+					},
+					Number: 12345,
+					PullRequest: github.PullRequest{
+						Title: "Conformance results for v1.27/coolkube",
+						User: github.User{
+							Login: "example",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "request not open or reopen",
+			args: args{
+				log: log,
+				pre: &github.PullRequestEvent{
+					Action: github.PullRequestActionClosed,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			productYAML := map[string]string{}
+			var productYAMLSupportingFile string
+			for _, file := range tt.supportingFiles {
+				if file.BaseName == "PRODUCT.yaml" {
+					productYAMLSupportingFile = file.Contents
+				}
+			}
+			if productYAMLSupportingFile != "" {
+				if err := yaml.Unmarshal([]byte(productYAMLSupportingFile), &productYAML); err != nil {
+					t.Fatalf("error: unmarshalling from PRODUCT.yaml supporting file: %v", err)
+				}
+			}
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Logf("requesting path '%v'", r.URL.Path)
+				supportingFile := &suite.PullRequestFile{}
+				for _, file := range tt.supportingFiles {
+					if r.URL.Path == "/"+file.BaseName || r.URL.Path == "/"+file.Name {
+						supportingFile = file
+					}
+				}
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(supportingFile.Contents))
+				if err != nil {
+					t.Fatalf("error: sending http response; %v", err)
+				}
+			}))
+			defer svr.Close()
+			for _, field := range []string{"website_url", "documentation_url"} {
+				if productYAML[field] != "" {
+					productYAML[field] = svr.URL + "/" + productYAML[field]
+				}
+			}
+			productYAMLBytes, err := yaml.Marshal(productYAML)
+			if err != nil {
+				t.Fatalf("error: marshalling new product yaml: %v", err)
+			}
+			for i := range tt.supportingFiles {
+				tt.supportingFiles[i].BlobURL = svr.URL + "/" + tt.supportingFiles[i].BlobURL
+				if tt.supportingFiles[i].BaseName == "PRODUCT.yaml" {
+					tt.supportingFiles[i].Contents = string(productYAMLBytes)
+				}
+			}
+			pullRequestQuery, err := NewPullRequestQueryForGithubPullRequest(tt.args.pre.Repo.Owner.Login, tt.args.pre.Repo.Name, tt.args.pre.Number, &tt.args.pre.PullRequest)
+			if err != nil {
+				// some cases deliberately use partially-populated events; the
+				// handler is expected to ignore or refuse them
+				pullRequestQuery = &suite.PullRequestQuery{Number: githubql.Int(tt.args.pre.Number)}
+			}
+			ghc := NewFakeGitHubClient([]*prContext{
+				{
+					PullRequestQuery: pullRequestQuery,
+					SupportingFiles:  tt.supportingFiles,
+				},
+			})
+			if err := HandlePullRequestEvent(tt.args.log, ghc, tt.args.pre); (err != nil) != tt.wantErr {
+				t.Errorf("HandlePullRequestEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandlePullRequestEventMalformed(t *testing.T) {
+	ghc := NewFakeGitHubClient([]*prContext{})
+	if err := HandlePullRequestEvent(log, ghc, nil); err == nil {
+		t.Fatalf("error: expected an error for an empty event")
+	}
+	pre := &github.PullRequestEvent{Action: github.PullRequestActionOpened}
+	if err := HandlePullRequestEvent(log, ghc, pre); err == nil {
+		t.Fatalf("error: expected an error for an event naming no repository")
+	}
+}
+
+func TestHandleIssueCommentEventMalformed(t *testing.T) {
+	ghc := NewFakeGitHubClient([]*prContext{})
+	if err := HandleIssueCommentEvent(log, ghc, nil); err == nil {
+		t.Fatalf("error: expected an error for an empty event")
+	}
+}
+
+func Test_resetLabels(t *testing.T) {
+	newEvent := func(commenter string) *github.IssueCommentEvent {
+		return &github.IssueCommentEvent{
+			Action: github.IssueCommentActionCreated,
+			Issue: github.Issue{
+				PullRequest: &struct{}{},
+				Number:      1,
+				User: github.User{
+					Login: "example",
+				},
+			},
+			Comment: github.IssueComment{
+				Body: "/verify-conformance reset-labels",
+				User: github.User{
+					Login: commenter,
+				},
+			},
+			Repo: github.Repo{
+				Owner: github.User{
+					Login: "cncf",
+				},
+				Name: "k8s-conformance",
+			},
+		}
+	}
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Number: githubql.Int(1),
+	}
+	pullRequestQuery.Labels.Nodes = []struct{ Name githubql.String }{
+		{Name: githubql.String("conformance-product-submission")},
+		{Name: githubql.String("release-v1.30")},
+		{Name: githubql.String("missing-file-e2e.log")},
+		{Name: githubql.String("not-verifiable")},
+		{Name: githubql.String("cncf-cla: yes")},
+		{Name: githubql.String("release-note")},
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+		},
+	})
+
+	// a request from outside the org is ignored, even from the PR author
+	if err := HandleIssueCommentEvent(log, ghc, newEvent("example")); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if labels := pullRequestQuery.Labels.Nodes; len(labels) != 6 {
+		t.Fatalf("error: expected a non-member request to be ignored; got %v label(s)", len(labels))
+	}
+
+	if err := HandleIssueCommentEvent(log, ghc, newEvent("org-member")); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	remainingLabels := []string{}
+	for _, l := range pullRequestQuery.Labels.Nodes {
+		remainingLabels = append(remainingLabels, string(l.Name))
+	}
+	if !reflect.DeepEqual(remainingLabels, []string{"cncf-cla: yes", "release-note"}) {
+		t.Fatalf("error: expected only unmanaged labels to remain; got %v", remainingLabels)
+	}
+	comments := ghc.PopulatedPullRequests[0].Comments
+	if len(comments) != 1 {
+		t.Fatalf("error: expected a confirmation comment; got %v comment(s)", len(comments))
+	}
+	if !strings.Contains(comments[0].Body, "Removed 4 managed label(s) by request") {
+		t.Fatalf("error: unexpected confirmation comment: %v", comments[0].Body)
+	}
+}
+
+func Test_overrideAdvisoryCheck(t *testing.T) {
+	suite.SetAdvisoryRequirements([]string{"the product logo is an SVG image"})
+	defer suite.SetAdvisoryRequirements([]string{})
+	newEvent := func(commenter string, body string) *github.IssueCommentEvent {
+		return &github.IssueCommentEvent{
+			Action: github.IssueCommentActionCreated,
+			Issue: github.Issue{
+				PullRequest: &struct{}{},
+				Number:      1,
+				User: github.User{
+					Login: "example",
+				},
+			},
+			Comment: github.IssueComment{
+				Body: body,
+				User: github.User{
+					Login: commenter,
+				},
+			},
+			Repo: github.Repo{
+				Owner: github.User{
+					Login: "cncf",
+				},
+				Name: "k8s-conformance",
+			},
+		}
+	}
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Number: githubql.Int(1),
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+		},
+	})
+
+	// a request from outside the org is ignored, even from the PR author
+	if err := HandleIssueCommentEvent(log, ghc, newEvent("example", "/verify-conformance override the product logo is an SVG image")); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if labels := pullRequestQuery.Labels.Nodes; len(labels) != 0 {
+		t.Fatalf("error: expected a non-member request to be ignored; got %v label(s)", len(labels))
+	}
+	if comments := ghc.PopulatedPullRequests[0].Comments; len(comments) != 0 {
+		t.Fatalf("error: expected a non-member request to be ignored; got %v comment(s)", len(comments))
+	}
+
+	// an org member can override an advisory check
+	if err := HandleIssueCommentEvent(log, ghc, newEvent("org-member", "/verify-conformance override the product logo is an SVG image")); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	foundOverrideLabel := false
+	for _, l := range pullRequestQuery.Labels.Nodes {
+		if string(l.Name) == "advisory-override-the-product-logo-is-an-svg-image" {
+			foundOverrideLabel = true
+		}
+	}
+	if !foundOverrideLabel {
+		t.Fatalf("error: expected the override label to be recorded; got %+v", pullRequestQuery.Labels.Nodes)
+	}
+	comments := ghc.PopulatedPullRequests[0].Comments
+	if len(comments) != 1 {
+		t.Fatalf("error: expected a confirmation comment; got %v comment(s)", len(comments))
+	}
+	if !strings.Contains(comments[0].Body, "has been overridden by @org-member") {
+		t.Fatalf("error: unexpected confirmation comment: %v", comments[0].Body)
+	}
+
+	// a blocking check cannot be overridden, even by an org member
+	if err := HandleIssueCommentEvent(log, ghc, newEvent("org-member", "/verify-conformance override all tests pass")); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	for _, l := range pullRequestQuery.Labels.Nodes {
+		if string(l.Name) == "advisory-override-all-tests-pass" {
+			t.Fatalf("error: a blocking check must not be overridable")
+		}
+	}
+	comments = ghc.PopulatedPullRequests[0].Comments
+	if len(comments) != 2 {
+		t.Fatalf("error: expected a refusal comment; got %v comment(s)", len(comments))
+	}
+	if !strings.Contains(comments[1].Body, "is not an advisory check and cannot be overridden") {
+		t.Fatalf("error: unexpected refusal comment: %v", comments[1].Body)
+	}
+}
+
+func Test_showStatus(t *testing.T) {
+	newEvent := func() *github.IssueCommentEvent {
+		return &github.IssueCommentEvent{
+			Action: github.IssueCommentActionCreated,
+			Issue: github.Issue{
+				PullRequest: &struct{}{},
+				Number:      1,
+				User: github.User{
+					Login: "example",
+				},
+			},
+			Comment: github.IssueComment{
+				Body: "/verify-conformance status",
+				User: github.User{
+					Login: "example",
+				},
+			},
+			Repo: github.Repo{
+				Owner: github.User{
+					Login: "cncf",
+				},
+				Name: "k8s-conformance",
+			},
+		}
+	}
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Number: githubql.Int(1),
+		Author: struct{ Login githubql.String }{
+			Login: githubql.String("example"),
+		},
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+		},
+	})
+
+	// seed the cache under the same key the event handler derives
+	pullRequest, err := ghc.GetPullRequest("cncf", "k8s-conformance", 1)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	seedQuery, err := NewPullRequestQueryForGithubPullRequest("cncf", "k8s-conformance", 1, pullRequest)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	cachedComment := "All requirements (20) have passed for the submission!"
+	storeComputedComment(seedQuery, cachedComment)
+	defer func() {
+		computedLabelsMutex.Lock()
+		defer computedLabelsMutex.Unlock()
+		delete(computedComments, computedLabelsKey(seedQuery))
+	}()
+
+	if err := HandleIssueCommentEvent(log, ghc, newEvent()); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	comments := ghc.PopulatedPullRequests[0].Comments
+	if len(comments) != 1 {
+		t.Fatalf("error: expected a status comment; got %v comment(s)", len(comments))
+	}
+	if comments[0].Body != cachedComment {
+		t.Fatalf("error: unexpected status comment: %v", comments[0].Body)
+	}
+
+	// without a recorded result the command falls back to a full run
+	freshGhc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles:  []*suite.PullRequestFile{},
+		},
+	})
+	computedLabelsMutex.Lock()
+	delete(computedComments, computedLabelsKey(seedQuery))
+	computedLabelsMutex.Unlock()
+	if err := HandleIssueCommentEvent(log, freshGhc, newEvent()); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	foundFullRunComment := false
+	for _, comment := range freshGhc.PopulatedPullRequests[0].Comments {
+		if strings.Contains(comment.Body, "changes no files") {
+			foundFullRunComment = true
+		}
+	}
+	if !foundFullRunComment {
+		t.Fatalf("error: expected the fallback full run to comment on the PR; got %+v", freshGhc.PopulatedPullRequests[0].Comments)
+	}
+}
+
+func Test_commentRequestsRecheck(t *testing.T) {
+	type testCase struct {
+		Body           string
+		ExpectedResult bool
+	}
+	for _, tc := range []testCase{
+		{
+			Body:           "/verify-conformance recheck",
+			ExpectedResult: true,
+		},
+		{
+			Body:           "updated the logs\n/verify-conformance recheck",
+			ExpectedResult: true,
+		},
+		{
+			Body:           "please run /verify-conformance recheck for me",
+			ExpectedResult: false,
+		},
+		{
+			Body:           "nice submission!",
+			ExpectedResult: false,
+		},
+		{
+			Body:           "",
+			ExpectedResult: false,
+		},
+	} {
+		if result := commentRequestsRecheck(tc.Body); result != tc.ExpectedResult {
+			t.Fatalf("error: comment body (%v) recheck result (%v) doesn't match what is expected (%v)", tc.Body, result, tc.ExpectedResult)
+		}
+	}
+}
+
+func TestHandleIssueCommentEvent(t *testing.T) {
+	type args struct {
+		log *logrus.Entry
+		ice *github.IssueCommentEvent
+	}
+	tests := []struct {
+		name             string
+		args             args
+		pullRequestQuery *suite.PullRequestQuery
+		supportingFiles  []*suite.PullRequestFile
+		wantErr          bool
+	}{
+		{
+			name: "basic",
+			pullRequestQuery: &suite.PullRequestQuery{
+				Number: githubql.Int(12345),
+				Title:  githubql.String("Conformance results for v1.27/coolkube"),
+				Commits: struct {
+					Nodes []struct {
+						Commit struct {
+							Oid    githubql.String
+							Status struct {
+								Contexts []struct {
+									Context githubql.String
+									State   githubql.String
+								}
+							}
+						}
+					}
+				}{
+					Nodes: []struct {
+						Commit struct {
+							Oid    githubql.String
+							Status struct {
+								Contexts []struct {
+									Context githubql.String
+									State   githubql.String
+								}
+							}
+						}
+					}{
+						{
+							Commit: struct {
+								Oid    githubql.String
+								Status struct {
+									Contexts []struct {
+										Context githubql.String
+										State   githubql.String
+									}
+								}
+							}{
+								Oid: githubql.String(""),
+								Status: struct {
+									Contexts []struct {
+										Context githubql.String
+										State   githubql.String
+									}
+								}{
+									Contexts: []struct {
+										Context githubql.String
+										State   githubql.String
+									}{
+										{
+											Context: githubql.String(""),
+											State:   githubql.String(""),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			supportingFiles: []*suite.PullRequestFile{
+				{
+					Name:     "v1.30/coolkube/README.md",
+					BaseName: "README.md",
+					Contents: `# coolkube
+> the coolest Kubernetes distribution
+
+## Generating conformance results
+
+1. create a coolkube cluster
+2. sonobuoy run --wait && sonobuoy results "$(sonobuoy retrieve)" && sonobuoy delete --wait`,
+					BlobURL: "README.md",
+				},
+				{
+					Name:     "v1.30/coolkube/PRODUCT.yaml",
+					BaseName: "PRODUCT.yaml",
+					Contents: `vendor: "cool"
+name: "coolkube"
+version: "v1.30"
+type: "distribution"
+description: "it's just all-round cool and probably the best k8s, idk"
+website_url: "website_url"
+documentation_url: "docs"
+contact_email_address: "sales@coolkubernetes.com"`,
+					BlobURL: "PRODUCT.yaml",
+				},
+				{
+					Name:     "v1.30/coolkube/e2e.log",
+					BaseName: "e2e.log",
+					Contents: "",
+					BlobURL:  "e2e.log",
+				},
+				{
+					Name:     "v1.30/coolkube/junit_01.xml",
+					BaseName: "junit_01.xml",
+					Contents: testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01xml,
+					BlobURL:  "junit_01.xml",
+				},
+			},
+			args: args{
+				log: log,
+				ice: &github.IssueCommentEvent{
+					Action: github.IssueCommentActionCreated,
+					Issue: github.Issue{
+						PullRequest: &struct{}{},
+						Number:      12345,
+						User: github.User{
+							Login: "example",
+						},
+					},
+					Comment: github.IssueComment{
+						Body: "/verify-conformance recheck",
+						User: github.User{
+							Login: "example",
+						},
+					},
+					Repo: github.Repo{
+						Owner: github.User{
+							Login: "cncf",
+						},
+						Name: "k8s-conformance",
+					},
+				},
+			},
+		},
+		{
+			name: "unrelated comment is ignored",
+			args: args{
+				log: log,
+				ice: &github.IssueCommentEvent{
+					Action: github.IssueCommentActionCreated,
+					Issue: github.Issue{
+						PullRequest: &struct{}{},
+						Number:      12345,
+						User: github.User{
+							Login: "example",
+						},
+					},
+					Comment: github.IssueComment{
+						Body: "nice submission!",
+						User: github.User{
+							Login: "example",
+						},
+					},
+					Repo: github.Repo{
+						Owner: github.User{
+							Login: "cncf",
+						},
+						Name: "k8s-conformance",
+					},
+				},
+			},
+		},
+		{
+			name: "recheck from a non-member is ignored",
+			args: args{
+				log: log,
+				ice: &github.IssueCommentEvent{
+					Action: github.IssueCommentActionCreated,
+					Issue: github.Issue{
+						PullRequest: &struct{}{},
+						Number:      12345,
+						User: github.User{
+							Login: "example",
+						},
+					},
+					Comment: github.IssueComment{
+						Body: "/verify-conformance recheck",
+						User: github.User{
+							Login: "passerby",
+						},
+					},
+					Repo: github.Repo{
+						Owner: github.User{
+							Login: "cncf",
+						},
+						Name: "k8s-conformance",
+					},
+				},
+			},
+		},
+		{
+			name: "not a pr",
+			args: args{
+				log: log,
+				ice: &github.IssueCommentEvent{
+					Action: github.IssueCommentActionCreated,
+					Issue: github.Issue{
+						Number: 101010,
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			productYAML := map[string]string{}
+			var productYAMLSupportingFile string
+			for _, file := range tt.supportingFiles {
 				if file.BaseName == "PRODUCT.yaml" {
 					productYAMLSupportingFile = file.Contents
 				}
@@ -1375,9 +4668,196 @@ contact_email_address: "sales@coolkubernetes.com"`,
 			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				t.Logf("requesting path '%v'", r.URL.Path)
 				supportingFile := &suite.PullRequestFile{}
-				for _, file := range tt.supportingFiles {
-					if r.URL.Path == "/"+file.BaseName || r.URL.Path == "/"+file.Name {
-						supportingFile = file
+				for _, file := range tt.supportingFiles {
+					if r.URL.Path == "/"+file.BaseName || r.URL.Path == "/"+file.Name {
+						supportingFile = file
+					}
+				}
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(supportingFile.Contents))
+				if err != nil {
+					t.Fatalf("error: sending http response; %v", err)
+				}
+			}))
+			defer svr.Close()
+			for _, field := range []string{"website_url", "documentation_url"} {
+				if productYAML[field] != "" {
+					productYAML[field] = svr.URL + "/" + productYAML[field]
+				}
+			}
+			productYAMLBytes, err := yaml.Marshal(productYAML)
+			if err != nil {
+				t.Fatalf("error: marshalling new product yaml: %v", err)
+			}
+			for i := range tt.supportingFiles {
+				tt.supportingFiles[i].BlobURL = svr.URL + "/" + tt.supportingFiles[i].BlobURL
+				if tt.supportingFiles[i].BaseName == "PRODUCT.yaml" {
+					tt.supportingFiles[i].Contents = string(productYAMLBytes)
+				}
+			}
+			ghc := NewFakeGitHubClient([]*prContext{
+				{
+					PullRequestQuery: tt.pullRequestQuery,
+					SupportingFiles:  tt.supportingFiles,
+				},
+			})
+			if err := HandleIssueCommentEvent(tt.args.log, ghc, tt.args.ice); (err != nil) != tt.wantErr {
+				t.Errorf("HandleIssueCommentEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandleAll(t *testing.T) {
+	type args struct {
+		log    *logrus.Entry
+		config *plugins.Configuration
+	}
+	tests := []struct {
+		name       string
+		prContexts []*prContext
+		args       args
+		wantErr    bool
+	}{
+		{
+			name: "basic",
+			prContexts: []*prContext{
+				{
+					PullRequestQuery: &suite.PullRequestQuery{
+						Number: githubql.Int(12345),
+						Title:  githubql.String("Conformance results for v1.27/coolkube"),
+						Commits: struct {
+							Nodes []struct {
+								Commit struct {
+									Oid    githubql.String
+									Status struct {
+										Contexts []struct {
+											Context githubql.String
+											State   githubql.String
+										}
+									}
+								}
+							}
+						}{
+							Nodes: []struct {
+								Commit struct {
+									Oid    githubql.String
+									Status struct {
+										Contexts []struct {
+											Context githubql.String
+											State   githubql.String
+										}
+									}
+								}
+							}{
+								{
+									Commit: struct {
+										Oid    githubql.String
+										Status struct {
+											Contexts []struct {
+												Context githubql.String
+												State   githubql.String
+											}
+										}
+									}{
+										Oid: githubql.String(""),
+										Status: struct {
+											Contexts []struct {
+												Context githubql.String
+												State   githubql.String
+											}
+										}{
+											Contexts: []struct {
+												Context githubql.String
+												State   githubql.String
+											}{
+												{
+													Context: githubql.String(""),
+													State:   githubql.String(""),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					SupportingFiles: []*suite.PullRequestFile{
+						{
+							Name:     "v1.30/coolkube/README.md",
+							BaseName: "README.md",
+							Contents: `# coolkube
+> the coolest Kubernetes distribution
+
+## Generating conformance results
+
+1. create a coolkube cluster
+2. sonobuoy run --wait && sonobuoy results "$(sonobuoy retrieve)" && sonobuoy delete --wait`,
+							BlobURL: "README.md",
+						},
+						{
+							Name:     "v1.30/coolkube/PRODUCT.yaml",
+							BaseName: "PRODUCT.yaml",
+							Contents: `vendor: "cool"
+name: "coolkube"
+version: "v1.30"
+type: "distribution"
+description: "it's just all-round cool and probably the best k8s, idk"
+website_url: "website_url"
+documentation_url: "docs"
+contact_email_address: "sales@coolkubernetes.com"`,
+							BlobURL: "PRODUCT.yaml",
+						},
+						{
+							Name:     "v1.30/coolkube/e2e.log",
+							BaseName: "e2e.log",
+							Contents: "",
+							BlobURL:  "e2e.log",
+						},
+						{
+							Name:     "v1.30/coolkube/junit_01.xml",
+							BaseName: "junit_01.xml",
+							Contents: testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01xml,
+							BlobURL:  "junit_01.xml",
+						},
+					},
+				},
+			},
+			args: args{
+				log: log,
+				config: &plugins.Configuration{
+					ExternalPlugins: map[string][]plugins.ExternalPlugin{
+						"cncf/k8s-conformance": {
+							{
+								Name: "verify-conformance",
+								Events: []string{
+									"issue_comment",
+									"pull_request",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "no config",
+			args: args{
+				log:    log,
+				config: &plugins.Configuration{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Logf("requesting path '%v'", r.URL.Path)
+				supportingFile := &suite.PullRequestFile{}
+				for _, prc := range tt.prContexts {
+					for _, file := range prc.SupportingFiles {
+						if r.URL.Path == "/"+file.BaseName || r.URL.Path == "/"+file.Name {
+							supportingFile = file
+						}
 					}
 				}
 				w.WriteHeader(http.StatusOK)
@@ -1387,227 +4867,694 @@ contact_email_address: "sales@coolkubernetes.com"`,
 				}
 			}))
 			defer svr.Close()
-			for _, field := range []string{"website_url", "documentation_url"} {
-				if productYAML[field] != "" {
-					productYAML[field] = svr.URL + "/" + productYAML[field]
+			for _, prc := range tt.prContexts {
+				productYAML := map[string]string{}
+				var productYAMLSupportingFile string
+				for _, file := range prc.SupportingFiles {
+					if file.BaseName == "PRODUCT.yaml" {
+						productYAMLSupportingFile = file.Contents
+					}
+				}
+				if productYAMLSupportingFile != "" {
+					if err := yaml.Unmarshal([]byte(productYAMLSupportingFile), &productYAML); err != nil {
+						t.Fatalf("error: unmarshalling from PRODUCT.yaml supporting file: %v", err)
+					}
+				}
+				for _, field := range []string{"website_url", "documentation_url"} {
+					if productYAML[field] != "" {
+						productYAML[field] = svr.URL + "/" + productYAML[field]
+					}
+				}
+				productYAMLBytes, err := yaml.Marshal(productYAML)
+				if err != nil {
+					t.Fatalf("error: marshalling new product yaml: %v", err)
 				}
+				for i := range prc.SupportingFiles {
+					prc.SupportingFiles[i].BlobURL = svr.URL + "/" + prc.SupportingFiles[i].BlobURL
+					if prc.SupportingFiles[i].BaseName == "PRODUCT.yaml" {
+						prc.SupportingFiles[i].Contents = string(productYAMLBytes)
+					}
+				}
+			}
+			ghc := NewFakeGitHubClient(tt.prContexts)
+			if err := HandleAll(tt.args.log, ghc, tt.args.config); (err != nil) != tt.wantErr {
+				t.Errorf("HandleAll() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandleAllOverlappingConfig(t *testing.T) {
+	config := &plugins.Configuration{
+		ExternalPlugins: map[string][]plugins.ExternalPlugin{
+			"cncf/k8s-conformance": {
+				{
+					Name:   "verify-conformance",
+					Events: []string{"issue_comment", "pull_request"},
+				},
+			},
+			"cncf/k8s-conformance-staging": {
+				{
+					Name:   "verify-conformance",
+					Events: []string{"issue_comment", "pull_request"},
+				},
+			},
+		},
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: &suite.PullRequestQuery{
+				Number: githubql.Int(12345),
+				Title:  githubql.String("soup recipes for winter"),
+				Repository: struct {
+					Name  githubql.String
+					Owner struct{ Login githubql.String }
+				}{
+					Name: githubql.String("k8s-conformance"),
+					Owner: struct{ Login githubql.String }{
+						Login: githubql.String("cncf"),
+					},
+				},
+			},
+		},
+	})
+	if err := HandleAll(log, ghc, config); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	// both configured repos live in the same org, which is swept once over
+	// two pages of search results
+	if ghc.SearchQueryCount != 2 {
+		t.Fatalf("error: expected 2 search queries for one org, found %v", ghc.SearchQueryCount)
+	}
+	if len(ghc.PullRequestChangesCalls) != 1 {
+		t.Fatalf("error: expected the PR to be handled once, found %v handlings", len(ghc.PullRequestChangesCalls))
+	}
+}
+
+func TestHandleAllEnterpriseOrg(t *testing.T) {
+	defaultGraphQLEndpoint := githubGraphQLEndpoint
+	defer func() {
+		githubGraphQLEndpoint = defaultGraphQLEndpoint
+	}()
+	if err := SetGitHubGraphQLEndpoint("https://github.example.com/api/graphql"); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	config := &plugins.Configuration{
+		ExternalPlugins: map[string][]plugins.ExternalPlugin{
+			"github.example.com/cncf-enterprise/k8s-conformance": {
+				{
+					Name:   "verify-conformance",
+					Events: []string{"issue_comment", "pull_request"},
+				},
+			},
+		},
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: &suite.PullRequestQuery{
+				Number: githubql.Int(12345),
+				Title:  githubql.String("soup recipes for winter"),
+				Repository: struct {
+					Name  githubql.String
+					Owner struct{ Login githubql.String }
+				}{
+					Name: githubql.String("k8s-conformance"),
+					Owner: struct{ Login githubql.String }{
+						Login: githubql.String("cncf-enterprise"),
+					},
+				},
+			},
+		},
+	})
+	if err := HandleAll(log, ghc, config); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	// the host prefix is stripped, scoping the search to the enterprise org
+	for _, org := range ghc.SearchedOrgs {
+		if org != "cncf-enterprise" {
+			t.Fatalf("error: expected searches to be scoped to org 'cncf-enterprise'; got '%v'", org)
+		}
+	}
+	if len(ghc.PullRequestChangesCalls) != 1 {
+		t.Fatalf("error: expected the PR to be handled once, found %v handlings", len(ghc.PullRequestChangesCalls))
+	}
+	// a host-prefixed repo not matching the endpoint is ignored rather than
+	// mis-scoped
+	if org, _, ok := orgRepoFromConfiguredRepo("github.elsewhere.com/cncf-enterprise/k8s-conformance"); ok {
+		t.Fatalf("error: expected a repo with an unrecognized host prefix to be ignored; got org '%v'", org)
+	}
+}
+
+// reAuthFakeGitHubClient simulates a GitHub App installation token expiring
+// mid-sweep: calls fail with a 401 until ReAuthenticate refreshes it
+type reAuthFakeGitHubClient struct {
+	*FakeGitHubClient
+	authExpired       bool
+	reAuthentications int
+}
+
+func (f *reAuthFakeGitHubClient) GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error) {
+	if f.authExpired {
+		return nil, fmt.Errorf("status code 401 not one of [200], body: Bad credentials")
+	}
+	return f.FakeGitHubClient.GetPullRequestChanges(org, repo, number)
+}
+
+func (f *reAuthFakeGitHubClient) ReAuthenticate() error {
+	f.reAuthentications++
+	f.authExpired = false
+	return nil
+}
+
+func TestHandleAllReAuthenticatesOnCredentialError(t *testing.T) {
+	if err := os.Setenv("KO_DATA_PATH", "./../../kodata"); err != nil {
+		log.Fatalf("failed to set env: %v", err)
+	}
+	config := &plugins.Configuration{
+		ExternalPlugins: map[string][]plugins.ExternalPlugin{
+			"cncf/k8s-conformance": {
+				{
+					Name:   "verify-conformance",
+					Events: []string{"issue_comment", "pull_request"},
+				},
+			},
+		},
+	}
+	ghc := &reAuthFakeGitHubClient{
+		FakeGitHubClient: NewFakeGitHubClient([]*prContext{
+			{
+				PullRequestQuery: &suite.PullRequestQuery{
+					Number: githubql.Int(12345),
+					Title:  githubql.String("Conformance results for v1.30/coolkube"),
+					Repository: struct {
+						Name  githubql.String
+						Owner struct{ Login githubql.String }
+					}{
+						Name: githubql.String("k8s-conformance"),
+						Owner: struct{ Login githubql.String }{
+							Login: githubql.String("cncf"),
+						},
+					},
+				},
+			},
+		}),
+		authExpired: true,
+	}
+	if err := HandleAll(log, ghc, config); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if ghc.reAuthentications != 1 {
+		t.Fatalf("error: expected the client to be re-authenticated once; got %v", ghc.reAuthentications)
+	}
+	// the retried attempt must have reached the fake and handled the PR
+	if len(ghc.PullRequestChangesCalls) != 1 {
+		t.Fatalf("error: expected the PR to be handled after re-authenticating, found %v handlings", len(ghc.PullRequestChangesCalls))
+	}
+	found := false
+	for _, comment := range ghc.PopulatedPullRequests[0].Comments {
+		if strings.Contains(comment.Body, "changes no files") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("error: expected the retried attempt to comment on the PR")
+	}
+}
+
+func TestNewHealthzHandler(t *testing.T) {
+	emptyDataPath := t.TempDir()
+	if err := os.MkdirAll(path.Join(emptyDataPath, "conformance-testdata"), 0755); err != nil {
+		t.Fatalf("error: creating empty metadata folder; %v", err)
+	}
+	if err := os.MkdirAll(path.Join(emptyDataPath, "metadata"), 0755); err != nil {
+		t.Fatalf("error: creating metadata folder; %v", err)
+	}
+	if err := os.WriteFile(path.Join(emptyDataPath, "metadata", "stable.txt"), []byte("v1.31.0\n"), 0644); err != nil {
+		t.Fatalf("error: writing stable.txt; %v", err)
+	}
+
+	type testCase struct {
+		Name            string
+		DataPath        string
+		ExpectedStatus  int
+		ExpectedHealthy bool
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:            "populated metadata reports healthy",
+			DataPath:        "./../../kodata",
+			ExpectedStatus:  http.StatusOK,
+			ExpectedHealthy: true,
+		},
+		{
+			Name:            "empty metadata folder reports unhealthy",
+			DataPath:        emptyDataPath,
+			ExpectedStatus:  http.StatusServiceUnavailable,
+			ExpectedHealthy: false,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Setenv("KO_DATA_PATH", tc.DataPath)
+			recorder := httptest.NewRecorder()
+			NewHealthzHandler(log).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+			if recorder.Code != tc.ExpectedStatus {
+				t.Fatalf("error: expected status %v; got %v", tc.ExpectedStatus, recorder.Code)
 			}
-			productYAMLBytes, err := yaml.Marshal(productYAML)
-			if err != nil {
-				t.Fatalf("error: marshalling new product yaml: %v", err)
+			response := map[string]interface{}{}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatalf("error: decoding the healthz response; %v", err)
 			}
-			for i := range tt.supportingFiles {
-				tt.supportingFiles[i].BlobURL = svr.URL + "/" + tt.supportingFiles[i].BlobURL
-				if tt.supportingFiles[i].BaseName == "PRODUCT.yaml" {
-					tt.supportingFiles[i].Contents = string(productYAMLBytes)
+			if healthy, _ := response["healthy"].(bool); healthy != tc.ExpectedHealthy {
+				t.Fatalf("error: expected healthy to be %v; got %v", tc.ExpectedHealthy, response["healthy"])
+			}
+			if !tc.ExpectedHealthy {
+				return
+			}
+			if version, _ := response["latestStableVersion"].(string); version != "v1.31.0" {
+				t.Fatalf("error: expected the latest stable version to be reported; got '%v'", response["latestStableVersion"])
+			}
+			directories, _ := response["releaseDirectories"].([]interface{})
+			foundRelease := false
+			for _, directory := range directories {
+				if directory == "v1.30" {
+					foundRelease = true
 				}
 			}
-			ghc := NewFakeGitHubClient([]*prContext{
-				{
-					PullRequestQuery: tt.pullRequestQuery,
-					SupportingFiles:  tt.supportingFiles,
-				},
-			})
-			if err := HandleIssueCommentEvent(tt.args.log, ghc, tt.args.ice); (err != nil) != tt.wantErr {
-				t.Errorf("HandleIssueCommentEvent() error = %v, wantErr %v", err, tt.wantErr)
+			if !foundRelease {
+				t.Fatalf("error: expected the release directories to include v1.30; got %v", response["releaseDirectories"])
 			}
 		})
 	}
 }
 
-func TestHandleAll(t *testing.T) {
-	type args struct {
-		log    *logrus.Entry
-		config *plugins.Configuration
+func TestHandleWithNoFeatureFiles(t *testing.T) {
+	if err := os.Setenv("KO_DATA_PATH", "./../../kodata"); err != nil {
+		log.Fatalf("failed to set env: %v", err)
 	}
-	tests := []struct {
-		name       string
-		prContexts []*prContext
-		args       args
-		wantErr    bool
-	}{
-		{
-			name: "basic",
-			prContexts: []*prContext{
-				{
-					PullRequestQuery: &suite.PullRequestQuery{
-						Number: githubql.Int(12345),
-						Title:  githubql.String("Conformance results for v1.27/coolkube"),
-						Commits: struct {
-							Nodes []struct {
-								Commit struct {
-									Oid    githubql.String
-									Status struct {
-										Contexts []struct {
-											Context githubql.String
-											State   githubql.String
-										}
-									}
-								}
-							}
-						}{
-							Nodes: []struct {
-								Commit struct {
-									Oid    githubql.String
-									Status struct {
-										Contexts []struct {
-											Context githubql.String
-											State   githubql.String
-										}
-									}
-								}
-							}{
-								{
-									Commit: struct {
-										Oid    githubql.String
-										Status struct {
-											Contexts []struct {
-												Context githubql.String
-												State   githubql.String
-											}
-										}
-									}{
-										Oid: githubql.String(""),
-										Status: struct {
-											Contexts []struct {
-												Context githubql.String
-												State   githubql.String
-											}
-										}{
-											Contexts: []struct {
-												Context githubql.String
-												State   githubql.String
-											}{
-												{
-													Context: githubql.String(""),
-													State:   githubql.String(""),
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-					SupportingFiles: []*suite.PullRequestFile{
-						{
-							Name:     "v1.30/coolkube/README.md",
-							BaseName: "README.md",
-							Contents: `# coolkube
-> the coolest Kubernetes distribution
+	t.Setenv("VERIFY_CONFORMANCE_FEATURE_PATHS", "")
+	originalGodogPaths := godogPaths
+	godogPaths = []string{"/somewhere-that-does-not-exist/"}
+	defer func() { godogPaths = originalGodogPaths }()
 
-## Generating conformance results
+	var supportingFiles []*suite.PullRequestFile
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, file := range supportingFiles {
+			if r.URL.Path == "/"+file.BaseName {
+				w.WriteHeader(http.StatusOK)
+				if _, err := w.Write([]byte(file.Contents)); err != nil {
+					t.Errorf("error: sending http response; %v", err)
+				}
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer svr.Close()
+	supportingFiles = []*suite.PullRequestFile{
+		{
+			Name:     "v1.30/coolkube/README.md",
+			BaseName: "README.md",
+			Contents: `# coolkube`,
+			BlobURL:  svr.URL + "/README.md",
+		},
+		{
+			Name:     "v1.30/coolkube/PRODUCT.yaml",
+			BaseName: "PRODUCT.yaml",
+			Contents: `vendor: "cool"
+name: "coolkube"
+version: "v1.30"`,
+			BlobURL: svr.URL + "/PRODUCT.yaml",
+		},
+	}
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Number: githubql.Int(0),
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles:  supportingFiles,
+		},
+	})
+	err := handle(context.TODO(), log, ghc, pullRequestQuery)
+	if err == nil || !strings.Contains(err.Error(), "unable to find any feature files") {
+		t.Fatalf("error: expected handle to fail closed without feature files; got %v", err)
+	}
+	for _, status := range ghc.PopulatedPullRequests[0].Statuses {
+		if status.State == "success" {
+			t.Fatalf("error: the PR must not be marked successful without feature files; got %+v", ghc.PopulatedPullRequests[0].Statuses)
+		}
+	}
+}
 
-1. create a coolkube cluster
-2. sonobuoy run --wait && sonobuoy results "$(sonobuoy retrieve)" && sonobuoy delete --wait`,
-							BlobURL: "README.md",
-						},
-						{
-							Name:     "v1.30/coolkube/PRODUCT.yaml",
-							BaseName: "PRODUCT.yaml",
-							Contents: `vendor: "cool"
+func TestNewVerifyHandler(t *testing.T) {
+	if err := os.Setenv("KO_DATA_PATH", "./../../kodata"); err != nil {
+		log.Fatalf("failed to set env: %v", err)
+	}
+	var supportingFiles []*suite.PullRequestFile
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, file := range supportingFiles {
+			if r.URL.Path == "/"+file.BaseName {
+				w.WriteHeader(http.StatusOK)
+				if _, err := w.Write([]byte(file.Contents)); err != nil {
+					t.Errorf("error: sending http response; %v", err)
+				}
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer svr.Close()
+	supportingFiles = []*suite.PullRequestFile{
+		{
+			Name:     "v1.30/coolkube/README.md",
+			BaseName: "README.md",
+			Contents: `# coolkube`,
+			BlobURL:  svr.URL + "/README.md",
+		},
+		{
+			Name:     "v1.30/coolkube/PRODUCT.yaml",
+			BaseName: "PRODUCT.yaml",
+			Contents: `vendor: "cool"
 name: "coolkube"
-version: "v1.30"
-type: "distribution"
-description: "it's just all-round cool and probably the best k8s, idk"
-website_url: "website_url"
-documentation_url: "docs"
-contact_email_address: "sales@coolkubernetes.com"`,
-							BlobURL: "PRODUCT.yaml",
-						},
-						{
-							Name:     "v1.30/coolkube/e2e.log",
-							BaseName: "e2e.log",
-							Contents: "",
-							BlobURL:  "e2e.log",
-						},
-						{
-							Name:     "v1.30/coolkube/junit_01.xml",
-							BaseName: "junit_01.xml",
-							Contents: testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01xml,
-							BlobURL:  "junit_01.xml",
-						},
-					},
-				},
+version: "v1.30"`,
+			BlobURL: svr.URL + "/PRODUCT.yaml",
+		},
+	}
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Number: githubql.Int(0),
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles:  supportingFiles,
+		},
+	})
+	handler := NewVerifyHandler(log, ghc, func() []byte { return []byte("sekret") })
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/verify?org=cncf&repo=k8s-conformance&number=0", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("error: expected a request without the token to be unauthorized; got %v", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/verify?org=cncf", nil)
+	req.Header.Set("Authorization", "Bearer sekret")
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("error: expected a request without a PR reference to be a bad request; got %v", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/verify?org=cncf&repo=k8s-conformance&number=0", nil)
+	req.Header.Set("Authorization", "Bearer sekret")
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("error: expected the verify request to succeed; got %v (%v)", rec.Code, rec.Body.String())
+	}
+	response := struct {
+		Labels []string `json:"labels"`
+		Status string   `json:"status"`
+	}{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("error: parsing the verify response; %v", err)
+	}
+	if response.Status != "success" {
+		t.Fatalf("error: expected the verify run to report success; got '%v'", response.Status)
+	}
+	foundLabel := false
+	for _, l := range response.Labels {
+		if l == "conformance-product-submission" {
+			foundLabel = true
+		}
+	}
+	if !foundLabel {
+		t.Fatalf("error: expected the label 'conformance-product-submission' in the verify response; got %v", response.Labels)
+	}
+}
+
+func TestRelabelRestoresRemovedLabel(t *testing.T) {
+	fetches := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:      githubql.String("Conformance results for v1.29/coolkube"),
+		Number:     githubql.Int(0),
+		HeadRefOID: githubql.String("abc123"),
+		Labels: struct {
+			Nodes []struct {
+				Name githubql.String
+			}
+		}{
+			Nodes: []struct {
+				Name githubql.String
+			}{
+				{Name: githubql.String("conformance-product-submission")},
 			},
-			args: args{
-				log: log,
-				config: &plugins.Configuration{
-					ExternalPlugins: map[string][]plugins.ExternalPlugin{
-						"cncf/k8s-conformance": {
-							{
-								Name: "verify-conformance",
-								Events: []string{
-									"issue_comment",
-									"pull_request",
-								},
-							},
-						},
-					},
+		},
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+			SupportingFiles: []*suite.PullRequestFile{
+				{
+					Name:     "v1.29/coolkube/e2e.log",
+					BaseName: "e2e.log",
+					BlobURL:  svr.URL + "/e2e.log",
 				},
 			},
 		},
+	})
+	storeComputedLabels(pullRequestQuery, []string{"conformance-product-submission", "release-v1.29"})
+	defer func() {
+		computedLabelsMutex.Lock()
+		delete(computedLabels, computedLabelsKey(pullRequestQuery))
+		computedLabelsMutex.Unlock()
+	}()
+	if err := relabel(context.TODO(), log, ghc, pullRequestQuery); err != nil {
+		t.Fatalf("error: relabelling must not fail; %v", err)
+	}
+	foundReleaseLabel := false
+	for _, l := range ghc.PopulatedPullRequests[0].PullRequestQuery.Labels.Nodes {
+		if string(l.Name) == "release-v1.29" {
+			foundReleaseLabel = true
+		}
+	}
+	if !foundReleaseLabel {
+		t.Fatalf("error: expected the removed label 'release-v1.29' to be restored")
+	}
+	if fetches != 0 {
+		t.Fatalf("error: expected no artifact fetches during relabelling; got %v", fetches)
+	}
+}
+
+func Test_evictStaleComputedResults(t *testing.T) {
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:      githubql.String("Conformance results for v1.29/coolkube"),
+		Number:     githubql.Int(0),
+		HeadRefOID: githubql.String("abc123"),
+	}
+	storeComputedLabels(pullRequestQuery, []string{"conformance-product-submission"})
+	storeComputedComment(pullRequestQuery, "some comment")
+	storeComputedState(pullRequestQuery, "success")
+	staleKey := computedLabelsKey(pullRequestQuery)
+	pullRequestQuery.HeadRefOID = githubql.String("def456")
+	storeComputedLabels(pullRequestQuery, []string{"conformance-product-submission", "release-v1.29"})
+	defer invalidateComputedResults(pullRequestQuery)
+	computedLabelsMutex.Lock()
+	defer computedLabelsMutex.Unlock()
+	if _, found := computedLabels[staleKey]; found {
+		t.Fatalf("error: expected the labels cached for the previous head to be evicted")
+	}
+	if _, found := computedComments[staleKey]; found {
+		t.Fatalf("error: expected the comment cached for the previous head to be evicted")
+	}
+	if _, found := computedStates[staleKey]; found {
+		t.Fatalf("error: expected the state cached for the previous head to be evicted")
+	}
+	if _, found := computedLabels[computedLabelsKey(pullRequestQuery)]; !found {
+		t.Fatalf("error: expected the labels for the current head to remain cached")
+	}
+}
+
+func TestHandleSkipsDraftPR(t *testing.T) {
+	if err := os.Setenv("KO_DATA_PATH", "./../../kodata"); err != nil {
+		log.Fatalf("failed to set env: %v", err)
+	}
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:   githubql.String("Conformance results for v1.30/coolkube"),
+		Number:  githubql.Int(0),
+		IsDraft: githubql.Boolean(true),
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+		},
+	})
+	if err := handle(context.TODO(), log, ghc, pullRequestQuery); err != nil {
+		t.Fatalf("error: handling a draft PR must not fail; %v", err)
+	}
+	if comments := ghc.PopulatedPullRequests[0].Comments; len(comments) != 0 {
+		t.Fatalf("error: expected no comments to be created on a draft PR; got %v", comments)
+	}
+	if statuses := ghc.PopulatedPullRequests[0].Statuses; len(statuses) != 0 {
+		t.Fatalf("error: expected no statuses to be created on a draft PR; got %v", statuses)
+	}
+}
+
+func TestHandlePullRequestEventReadyForReview(t *testing.T) {
+	if err := os.Setenv("KO_DATA_PATH", "./../../kodata"); err != nil {
+		log.Fatalf("failed to set env: %v", err)
+	}
+	pre := &github.PullRequestEvent{
+		Action: github.PullRequestActionReadyForReview,
+		Repo: github.Repo{
+			Owner: github.User{
+				Login: "cncf",
+			},
+			Name: "k8s-conformance",
+		},
+		Number: 12345,
+		PullRequest: github.PullRequest{
+			Title: "Conformance results for v1.30/coolkube",
+			User: github.User{
+				Login: "example",
+			},
+		},
+	}
+	pullRequestQuery, err := NewPullRequestQueryForGithubPullRequest(pre.Repo.Owner.Login, pre.Repo.Name, pre.Number, &pre.PullRequest)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+		},
+	})
+	// the fixture has no supporting files, so the run itself is expected to
+	// fail; what matters here is that the event triggered it
+	if err := HandlePullRequestEvent(log, ghc, pre); err != nil && !strings.Contains(err.Error(), "unable to process release file") {
+		t.Fatalf("unexpected error handling a ready-for-review event; %v", err)
+	}
+	if statuses := ghc.PopulatedPullRequests[0].Statuses; len(statuses) == 0 {
+		t.Fatalf("error: expected a ready-for-review event to trigger a run")
+	}
+}
+
+func TestHandleAllCancelled(t *testing.T) {
+	if err := os.Setenv("KO_DATA_PATH", "./../../kodata"); err != nil {
+		log.Fatalf("failed to set env: %v", err)
+	}
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Number: githubql.Int(0),
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
 		{
-			name: "no config",
-			args: args{
-				log:    log,
-				config: &plugins.Configuration{},
+			PullRequestQuery: pullRequestQuery,
+		},
+	})
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	if err := handleAll(ctx, log, ghc, &plugins.Configuration{
+		ExternalPlugins: map[string][]plugins.ExternalPlugin{
+			"cncf/k8s-conformance": {
+				{
+					Name: "verify-conformance",
+					Events: []string{
+						"issue_comment",
+						"pull_request",
+					},
+				},
 			},
 		},
+	}); err != nil {
+		t.Fatalf("error: a cancelled scan must terminate gracefully; %v", err)
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				t.Logf("requesting path '%v'", r.URL.Path)
-				supportingFile := &suite.PullRequestFile{}
-				for _, prc := range tt.prContexts {
-					for _, file := range prc.SupportingFiles {
-						if r.URL.Path == "/"+file.BaseName || r.URL.Path == "/"+file.Name {
-							supportingFile = file
-						}
-					}
-				}
-				w.WriteHeader(http.StatusOK)
-				_, err := w.Write([]byte(supportingFile.Contents))
-				if err != nil {
-					t.Fatalf("error: sending http response; %v", err)
-				}
-			}))
-			defer svr.Close()
-			for _, prc := range tt.prContexts {
-				productYAML := map[string]string{}
-				var productYAMLSupportingFile string
-				for _, file := range prc.SupportingFiles {
-					if file.BaseName == "PRODUCT.yaml" {
-						productYAMLSupportingFile = file.Contents
-					}
-				}
-				if productYAMLSupportingFile != "" {
-					if err := yaml.Unmarshal([]byte(productYAMLSupportingFile), &productYAML); err != nil {
-						t.Fatalf("error: unmarshalling from PRODUCT.yaml supporting file: %v", err)
-					}
-				}
-				for _, field := range []string{"website_url", "documentation_url"} {
-					if productYAML[field] != "" {
-						productYAML[field] = svr.URL + "/" + productYAML[field]
-					}
-				}
-				productYAMLBytes, err := yaml.Marshal(productYAML)
-				if err != nil {
-					t.Fatalf("error: marshalling new product yaml: %v", err)
-				}
-				for i := range prc.SupportingFiles {
-					prc.SupportingFiles[i].BlobURL = svr.URL + "/" + prc.SupportingFiles[i].BlobURL
-					if prc.SupportingFiles[i].BaseName == "PRODUCT.yaml" {
-						prc.SupportingFiles[i].Contents = string(productYAMLBytes)
-					}
-				}
-			}
-			ghc := NewFakeGitHubClient(tt.prContexts)
-			if err := HandleAll(tt.args.log, ghc, tt.args.config); (err != nil) != tt.wantErr {
-				t.Errorf("HandleAll() error = %v, wantErr %v", err, tt.wantErr)
-			}
-		})
+	if comments := ghc.PopulatedPullRequests[0].Comments; len(comments) != 0 {
+		t.Fatalf("error: expected no comments to be created after cancellation; got %v", comments)
+	}
+	if statuses := ghc.PopulatedPullRequests[0].Statuses; len(statuses) != 0 {
+		t.Fatalf("error: expected no statuses to be created after cancellation; got %v", statuses)
+	}
+}
+
+func Test_handlePRsConcurrently(t *testing.T) {
+	originalConcurrency := handleAllConcurrency
+	defer SetHandleAllConcurrency(originalConcurrency)
+	SetHandleAllConcurrency(2)
+
+	prs := []suite.PullRequestQuery{}
+	for i := 0; i < 10; i++ {
+		prs = append(prs, suite.PullRequestQuery{Number: githubql.Int(i)})
+	}
+	var mu sync.Mutex
+	handled := 0
+	inFlight := 0
+	maxInFlight := 0
+	handlePRsConcurrently(prs, func(pr *suite.PullRequestQuery) {
+		mu.Lock()
+		handled++
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	})
+	if handled != len(prs) {
+		t.Fatalf("error: expected %v PRs to be handled, got %v", len(prs), handled)
+	}
+	if maxInFlight > 2 {
+		t.Fatalf("error: expected at most 2 PRs in flight, got %v", maxInFlight)
+	}
+}
+
+func Test_handlePRsConcurrentlyStagger(t *testing.T) {
+	originalStagger := handleAllStagger
+	originalSleep := sleep
+	defer func() {
+		handleAllStagger = originalStagger
+		sleep = originalSleep
+	}()
+	SetHandleAllStagger(50 * time.Millisecond)
+	sleeps := []time.Duration{}
+	sleep = func(d time.Duration) {
+		sleeps = append(sleeps, d)
+	}
+
+	prs := []suite.PullRequestQuery{}
+	for i := 0; i < 4; i++ {
+		prs = append(prs, suite.PullRequestQuery{Number: githubql.Int(i)})
+	}
+	handled := 0
+	var mu sync.Mutex
+	handlePRsConcurrently(prs, func(pr *suite.PullRequestQuery) {
+		mu.Lock()
+		handled++
+		mu.Unlock()
+	})
+	if handled != len(prs) {
+		t.Fatalf("error: expected %v PRs to be handled, got %v", len(prs), handled)
+	}
+	// the first PR is dispatched immediately, with a pause before each later
+	// one
+	if len(sleeps) != len(prs)-1 {
+		t.Fatalf("error: expected %v pauses between dispatches, got %v", len(prs)-1, len(sleeps))
+	}
+	for _, d := range sleeps {
+		if d != 50*time.Millisecond {
+			t.Fatalf("error: expected pauses of 50ms, got %v", sleeps)
+		}
 	}
 }
 
@@ -1625,70 +5572,511 @@ func TestNewGitHubPullRequestForPullRequestQuery(t *testing.T) {
 	}{
 		// TODO: Add test cases.
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := NewGitHubPullRequestForPullRequestQuery(tt.args.orgName, tt.args.repoName, tt.args.number, tt.args.pr); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("NewGitHubPullRequestForPullRequestQuery() = %v, want %v", got, tt.want)
-			}
-		})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewGitHubPullRequestForPullRequestQuery(tt.args.orgName, tt.args.repoName, tt.args.number, tt.args.pr); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewGitHubPullRequestForPullRequestQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_updateLabels(t *testing.T) {
+	type args struct {
+		log     *logrus.Entry
+		ghc     githubClient
+		pr      *suite.PullRequestQuery
+		prSuite *suite.PRSuite
+		labels  []string
+	}
+	newTestArgs := func(existingLabels, labels []string) args {
+		pullRequestQuery := &suite.PullRequestQuery{
+			Number: githubql.Int(0),
+		}
+		for _, l := range existingLabels {
+			pullRequestQuery.Labels.Nodes = append(pullRequestQuery.Labels.Nodes, struct{ Name githubql.String }{githubql.String(l)})
+		}
+		prSuite := suite.NewPRSuite(&suite.PullRequest{
+			PullRequestQuery: *pullRequestQuery,
+		})
+		prSuite.KubernetesReleaseVersion = "v1.30"
+		prSuite.PR.Labels = existingLabels
+		return args{
+			log: log,
+			ghc: NewFakeGitHubClient([]*prContext{
+				{
+					PullRequestQuery: pullRequestQuery,
+				},
+			}),
+			pr:      pullRequestQuery,
+			prSuite: prSuite,
+			labels:  labels,
+		}
+	}
+	tests := []struct {
+		name              string
+		args              args
+		wantNewLabels     []string
+		wantRemovedLabels []string
+		wantErr           bool
+	}{
+		{
+			name: "stale labels are removed and new labels added",
+			args: newTestArgs(
+				[]string{"conformance-product-submission", "unable-to-process", "release-v1.29", "lgtm"},
+				[]string{"conformance-product-submission", "release-v1.30", "release-documents-checked"},
+			),
+			wantNewLabels:     []string{"release-v1.30", "release-documents-checked"},
+			wantRemovedLabels: []string{"unable-to-process", "release-v1.29"},
+		},
+		{
+			name: "nothing to change",
+			args: newTestArgs(
+				[]string{"conformance-product-submission"},
+				[]string{"conformance-product-submission"},
+			),
+		},
+		{
+			name: "duplicated labels are reconciled once",
+			args: newTestArgs(
+				[]string{"unable-to-process", "unable-to-process"},
+				[]string{"conformance-product-submission", "conformance-product-submission", "release-documents-checked"},
+			),
+			wantNewLabels:     []string{"conformance-product-submission", "release-documents-checked"},
+			wantRemovedLabels: []string{"unable-to-process"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotNewLabels, gotRemovedLabels, err := updateLabels(tt.args.log, tt.args.ghc, tt.args.pr, tt.args.prSuite, tt.args.labels)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("updateLabels() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotNewLabels, tt.wantNewLabels) {
+				t.Errorf("updateLabels() gotNewLabels = %v, want %v", gotNewLabels, tt.wantNewLabels)
+			}
+			if !reflect.DeepEqual(gotRemovedLabels, tt.wantRemovedLabels) {
+				t.Errorf("updateLabels() gotRemovedLabels = %v, want %v", gotRemovedLabels, tt.wantRemovedLabels)
+			}
+		})
+	}
+}
+
+// labelReplacingFakeGitHubClient can replace the full label set of a PR in
+// one request, and counts which of the label paths are taken
+type labelReplacingFakeGitHubClient struct {
+	*FakeGitHubClient
+	ReplaceLabelsCalls int
+	AddLabelCalls      int
+	RemoveLabelCalls   int
+}
+
+func (f *labelReplacingFakeGitHubClient) AddLabel(org, repo string, number int, label string) error {
+	f.AddLabelCalls++
+	return f.FakeGitHubClient.AddLabel(org, repo, number, label)
+}
+
+func (f *labelReplacingFakeGitHubClient) RemoveLabel(org, repo string, number int, label string) error {
+	f.RemoveLabelCalls++
+	return f.FakeGitHubClient.RemoveLabel(org, repo, number, label)
+}
+
+func (f *labelReplacingFakeGitHubClient) ReplaceLabels(org, repo string, number int, labels []string) error {
+	f.ReplaceLabelsCalls++
+	nodes := []struct{ Name githubql.String }{}
+	for _, l := range labels {
+		nodes = append(nodes, struct{ Name githubql.String }{githubql.String(l)})
+	}
+	f.PopulatedPullRequests[0].PullRequestQuery.Labels.Nodes = nodes
+	return nil
+}
+
+func Test_updateLabelsBatchesMultipleChanges(t *testing.T) {
+	newTestFixture := func(existingLabels []string) (*suite.PullRequestQuery, *suite.PRSuite, *labelReplacingFakeGitHubClient) {
+		pullRequestQuery := &suite.PullRequestQuery{
+			Number: githubql.Int(0),
+		}
+		for _, l := range existingLabels {
+			pullRequestQuery.Labels.Nodes = append(pullRequestQuery.Labels.Nodes, struct{ Name githubql.String }{githubql.String(l)})
+		}
+		prSuite := suite.NewPRSuite(&suite.PullRequest{
+			PullRequestQuery: *pullRequestQuery,
+		})
+		prSuite.PR.Labels = existingLabels
+		ghc := &labelReplacingFakeGitHubClient{FakeGitHubClient: NewFakeGitHubClient([]*prContext{
+			{
+				PullRequestQuery: pullRequestQuery,
+			},
+		})}
+		return pullRequestQuery, prSuite, ghc
+	}
+
+	// several changes go through one replacement of the full label set, with
+	// unmanaged labels carried over
+	pullRequestQuery, prSuite, ghc := newTestFixture([]string{"not-verifiable", "do-not-merge"})
+	newLabels, removedLabels, err := updateLabels(log, ghc, pullRequestQuery, prSuite, []string{"conformance-product-submission", "release-documents-checked"})
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if !reflect.DeepEqual(newLabels, []string{"conformance-product-submission", "release-documents-checked"}) {
+		t.Fatalf("error: unexpected new labels: %v", newLabels)
+	}
+	if !reflect.DeepEqual(removedLabels, []string{"not-verifiable"}) {
+		t.Fatalf("error: unexpected removed labels: %v", removedLabels)
+	}
+	if ghc.ReplaceLabelsCalls != 1 || ghc.AddLabelCalls != 0 || ghc.RemoveLabelCalls != 0 {
+		t.Fatalf("error: expected one batched replacement and no individual calls; got %v replacement(s), %v add(s), %v removal(s)", ghc.ReplaceLabelsCalls, ghc.AddLabelCalls, ghc.RemoveLabelCalls)
+	}
+	labelsOnPR := []string{}
+	for _, l := range ghc.PopulatedPullRequests[0].PullRequestQuery.Labels.Nodes {
+		labelsOnPR = append(labelsOnPR, string(l.Name))
+	}
+	if !reflect.DeepEqual(labelsOnPR, []string{"do-not-merge", "conformance-product-submission", "release-documents-checked"}) {
+		t.Fatalf("error: unexpected labels on the PR: %v", labelsOnPR)
+	}
+
+	// a single change still goes through an individual call
+	pullRequestQuery, prSuite, ghc = newTestFixture([]string{})
+	if _, _, err := updateLabels(log, ghc, pullRequestQuery, prSuite, []string{"conformance-product-submission"}); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if ghc.ReplaceLabelsCalls != 0 || ghc.AddLabelCalls != 1 {
+		t.Fatalf("error: expected one individual add and no replacements; got %v replacement(s), %v add(s)", ghc.ReplaceLabelsCalls, ghc.AddLabelCalls)
+	}
+}
+
+// botAwareFakeGitHubClient recognizes the fake's own comment author as the
+// bot, so comment deduplication can be exercised
+type botAwareFakeGitHubClient struct {
+	*FakeGitHubClient
+}
+
+func (f *botAwareFakeGitHubClient) BotUserChecker() (func(candidate string) bool, error) {
+	return func(candidate string) bool { return candidate == "cncfci(bot)" }, nil
+}
+
+func Test_updateComments(t *testing.T) {
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Number: githubql.Int(0),
+	}
+	ghc := &botAwareFakeGitHubClient{NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+		},
+	})}
+	prSuite := suite.NewPRSuite(&suite.PullRequest{PullRequestQuery: *pullRequestQuery})
+	comment := "All requirements (20) have passed for the submission!"
+	if err := updateComments(log, ghc, pullRequestQuery, prSuite, comment); err != nil {
+		t.Fatalf("error: posting the first comment must not fail; %v", err)
+	}
+	if comments := ghc.PopulatedPullRequests[0].Comments; len(comments) != 1 {
+		t.Fatalf("error: expected one comment; got %v", len(comments))
+	}
+	// a restarted process recomputes the same comment and must not re-post it
+	if err := updateComments(log, ghc, pullRequestQuery, prSuite, comment); err != nil {
+		t.Fatalf("error: re-posting the same comment must not fail; %v", err)
+	}
+	if comments := ghc.PopulatedPullRequests[0].Comments; len(comments) != 1 {
+		t.Fatalf("error: expected the identical comment to be deduplicated; got %v comment(s)", len(comments))
+	}
+	// formatting-only whitespace differences must also be recognized
+	if err := updateComments(log, ghc, pullRequestQuery, prSuite, "All requirements (20)  have passed\nfor the submission!"); err != nil {
+		t.Fatalf("error: re-posting a reformatted comment must not fail; %v", err)
+	}
+	if comments := ghc.PopulatedPullRequests[0].Comments; len(comments) != 1 {
+		t.Fatalf("error: expected the reformatted comment to be deduplicated; got %v comment(s)", len(comments))
+	}
+	// genuinely new content is still posted
+	if err := updateComments(log, ghc, pullRequestQuery, prSuite, "19 of 20 requirements have passed."); err != nil {
+		t.Fatalf("error: posting new content must not fail; %v", err)
+	}
+	if comments := ghc.PopulatedPullRequests[0].Comments; len(comments) != 2 {
+		t.Fatalf("error: expected new content to be posted; got %v comment(s)", len(comments))
+	}
+}
+
+func Test_updateCommentsWithFooter(t *testing.T) {
+	defaultCommentFooter := commentFooter
+	defer func() {
+		commentFooter = defaultCommentFooter
+	}()
+	SetCommentFooter("Questions? Ping @k8s-conformance or see the [docs](https://github.com/cncf/k8s-conformance/blob/master/instructions.md).")
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Number: githubql.Int(0),
+	}
+	ghc := &botAwareFakeGitHubClient{NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+		},
+	})}
+	prSuite := suite.NewPRSuite(&suite.PullRequest{PullRequestQuery: *pullRequestQuery})
+	comment := "All requirements (20) have passed for the submission!"
+	if err := updateComments(log, ghc, pullRequestQuery, prSuite, comment); err != nil {
+		t.Fatalf("error: posting the first comment must not fail; %v", err)
+	}
+	comments := ghc.PopulatedPullRequests[0].Comments
+	if len(comments) != 1 {
+		t.Fatalf("error: expected one comment; got %v", len(comments))
+	}
+	if !strings.Contains(comments[0].Body, "\n---\nQuestions? Ping @k8s-conformance") {
+		t.Fatalf("error: expected the footer to be appended; got '%v'", comments[0].Body)
+	}
+	// the same comment with the same footer must still be deduplicated
+	if err := updateComments(log, ghc, pullRequestQuery, prSuite, comment); err != nil {
+		t.Fatalf("error: re-posting the same comment must not fail; %v", err)
+	}
+	if comments := ghc.PopulatedPullRequests[0].Comments; len(comments) != 1 {
+		t.Fatalf("error: expected the identical comment to be deduplicated; got %v comment(s)", len(comments))
+	}
+}
+
+func Test_updateCommentsRetainHistory(t *testing.T) {
+	defaultRetainCommentHistory := retainCommentHistory
+	defer func() {
+		retainCommentHistory = defaultRetainCommentHistory
+	}()
+	SetRetainCommentHistory(true)
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Number: githubql.Int(0),
+	}
+	ghc := &botAwareFakeGitHubClient{NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+		},
+	})}
+	prSuite := suite.NewPRSuite(&suite.PullRequest{PullRequestQuery: *pullRequestQuery})
+	if err := updateComments(log, ghc, pullRequestQuery, prSuite, "19 of 20 requirements have passed."); err != nil {
+		t.Fatalf("error: posting the first comment must not fail; %v", err)
+	}
+	// the same comment posted twice in a row must still be deduplicated
+	if err := updateComments(log, ghc, pullRequestQuery, prSuite, "19 of 20 requirements have passed."); err != nil {
+		t.Fatalf("error: re-posting the same comment must not fail; %v", err)
+	}
+	if comments := ghc.PopulatedPullRequests[0].Comments; len(comments) != 1 {
+		t.Fatalf("error: expected the identical comment to be deduplicated; got %v comment(s)", len(comments))
+	}
+	if err := updateComments(log, ghc, pullRequestQuery, prSuite, "All requirements (20) have passed for the submission!"); err != nil {
+		t.Fatalf("error: posting new content must not fail; %v", err)
+	}
+	if comments := ghc.PopulatedPullRequests[0].Comments; len(comments) != 2 {
+		t.Fatalf("error: expected the earlier comment to be kept; got %v comment(s)", len(comments))
+	}
+	// an earlier state that recurs is recorded again, so the comment
+	// history reflects the oscillation
+	if err := updateComments(log, ghc, pullRequestQuery, prSuite, "19 of 20 requirements have passed."); err != nil {
+		t.Fatalf("error: re-posting an earlier state must not fail; %v", err)
+	}
+	if comments := ghc.PopulatedPullRequests[0].Comments; len(comments) != 3 {
+		t.Fatalf("error: expected the recurring state to be recorded; got %v comment(s)", len(comments))
+	}
+	if ghc.StaleCommentDeletions != 0 {
+		t.Fatalf("error: expected no stale comment deletions with history retained; got %v", ghc.StaleCommentDeletions)
+	}
+	// with retention off again, posting new content prunes the history
+	SetRetainCommentHistory(false)
+	if err := updateComments(log, ghc, pullRequestQuery, prSuite, "18 of 20 requirements have passed."); err != nil {
+		t.Fatalf("error: posting with pruning re-enabled must not fail; %v", err)
+	}
+	if ghc.StaleCommentDeletions != 1 {
+		t.Fatalf("error: expected one stale comment deletion with pruning re-enabled; got %v", ghc.StaleCommentDeletions)
+	}
+}
+
+func TestSetOrgOverrides(t *testing.T) {
+	defaultOrgOverrides := orgOverrides
+	defer func() {
+		orgOverrides = defaultOrgOverrides
+	}()
+	if err := SetOrgOverrides(map[string]OrgOverrides{
+		"cncf-infra": {
+			CommentFooter:     common.Pointer("Questions? Ping @cncf-infra."),
+			SuccessComment:    common.Pointer("Nice work!"),
+			FeatureTagFilter:  common.Pointer("@product-yaml"),
+			SupportedReleases: common.Pointer(4),
+		},
+	}); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	// the override takes precedence for its org, and only its org
+	if footer := commentFooterForOrg("cncf-infra"); footer != "Questions? Ping @cncf-infra." {
+		t.Fatalf("error: unexpected footer for overridden org: '%v'", footer)
+	}
+	if footer := commentFooterForOrg("cncf"); footer != commentFooter {
+		t.Fatalf("error: expected the global footer for an org without overrides; got '%v'", footer)
+	}
+	if comment := successCommentForOrg("cncf-infra"); comment != "Nice work!" {
+		t.Fatalf("error: unexpected success comment for overridden org: '%v'", comment)
+	}
+	if tags := featureTagFilterForOrg("cncf-infra"); tags != "@product-yaml" {
+		t.Fatalf("error: unexpected tag filter for overridden org: '%v'", tags)
+	}
+	if window := supportedReleasesForOrg("cncf-infra"); window == nil || *window != 4 {
+		t.Fatalf("error: unexpected supported releases for overridden org: %v", window)
+	}
+	if window := supportedReleasesForOrg("cncf"); window != nil {
+		t.Fatalf("error: expected no supported releases override for an org without overrides; got %v", *window)
+	}
+	// invalid configurations are refused at startup
+	if err := SetOrgOverrides(map[string]OrgOverrides{" ": {}}); err == nil {
+		t.Fatalf("error: expected an error for an empty org login")
+	}
+	if err := SetOrgOverrides(map[string]OrgOverrides{"cncf": {SupportedReleases: common.Pointer(-1)}}); err == nil {
+		t.Fatalf("error: expected an error for a negative supported releases override")
+	}
+}
+
+func TestSetOrgOverridesFromFile(t *testing.T) {
+	defaultOrgOverrides := orgOverrides
+	defer func() {
+		orgOverrides = defaultOrgOverrides
+	}()
+	overridesPath := path.Join(t.TempDir(), "overrides.yaml")
+	overridesYAML := `cncf-infra:
+  successComment: "Nice work!"
+  supportedReleases: 3
+`
+	if err := os.WriteFile(overridesPath, []byte(overridesYAML), 0644); err != nil {
+		t.Fatalf("error: writing the overrides file; %v", err)
+	}
+	if err := SetOrgOverridesFromFile(overridesPath); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if comment := successCommentForOrg("cncf-infra"); comment != "Nice work!" {
+		t.Fatalf("error: unexpected success comment for overridden org: '%v'", comment)
+	}
+	if window := supportedReleasesForOrg("cncf-infra"); window == nil || *window != 3 {
+		t.Fatalf("error: unexpected supported releases for overridden org: %v", window)
+	}
+	if err := SetOrgOverridesFromFile(path.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Fatalf("error: expected an error for a missing overrides file")
+	}
+}
+
+func Test_updateCommentsOrgFooterOverride(t *testing.T) {
+	defaultCommentFooter := commentFooter
+	defaultOrgOverrides := orgOverrides
+	defer func() {
+		commentFooter = defaultCommentFooter
+		orgOverrides = defaultOrgOverrides
+	}()
+	SetCommentFooter("See the global docs.")
+	if err := SetOrgOverrides(map[string]OrgOverrides{
+		"cncf-infra": {
+			CommentFooter: common.Pointer("Questions? Ping @cncf-infra."),
+		},
+	}); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Number: githubql.Int(0),
+		Repository: struct {
+			Name  githubql.String
+			Owner struct{ Login githubql.String }
+		}{
+			Name: githubql.String("k8s-conformance"),
+			Owner: struct{ Login githubql.String }{
+				Login: githubql.String("cncf-infra"),
+			},
+		},
+	}
+	ghc := &botAwareFakeGitHubClient{NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+		},
+	})}
+	prSuite := suite.NewPRSuite(&suite.PullRequest{PullRequestQuery: *pullRequestQuery})
+	if err := updateComments(log, ghc, pullRequestQuery, prSuite, "All requirements (21) have passed for the submission!"); err != nil {
+		t.Fatalf("error: posting the comment must not fail; %v", err)
+	}
+	comments := ghc.PopulatedPullRequests[0].Comments
+	if len(comments) != 1 {
+		t.Fatalf("error: expected one comment; got %v", len(comments))
+	}
+	if !strings.Contains(comments[0].Body, "\n---\nQuestions? Ping @cncf-infra.") {
+		t.Fatalf("error: expected the org footer to take precedence; got '%v'", comments[0].Body)
+	}
+	if strings.Contains(comments[0].Body, "See the global docs.") {
+		t.Fatalf("error: the global footer must not be appended for an overridden org; got '%v'", comments[0].Body)
 	}
 }
 
-func Test_updateLabels(t *testing.T) {
-	type args struct {
-		log     *logrus.Entry
-		ghc     githubClient
-		pr      *suite.PullRequestQuery
-		prSuite *suite.PRSuite
-		labels  []string
+func Test_checkStaleSubmission(t *testing.T) {
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Number: githubql.Int(1),
 	}
-	tests := []struct {
-		name              string
-		args              args
-		wantNewLabels     []string
-		wantRemovedLabels []string
-		wantErr           bool
-	}{
-		// TODO: Add test cases.
+	pullRequestQuery.Labels.Nodes = []struct{ Name githubql.String }{
+		{Name: githubql.String("conformance-product-submission")},
+		{Name: githubql.String("failed-tests-present")},
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			gotNewLabels, gotRemovedLabels, err := updateLabels(tt.args.log, tt.args.ghc, tt.args.pr, tt.args.prSuite, tt.args.labels)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("updateLabels() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if !reflect.DeepEqual(gotNewLabels, tt.wantNewLabels) {
-				t.Errorf("updateLabels() gotNewLabels = %v, want %v", gotNewLabels, tt.wantNewLabels)
-			}
-			if !reflect.DeepEqual(gotRemovedLabels, tt.wantRemovedLabels) {
-				t.Errorf("updateLabels() gotRemovedLabels = %v, want %v", gotRemovedLabels, tt.wantRemovedLabels)
-			}
-		})
+	ghc := &botAwareFakeGitHubClient{NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+		},
+	})}
+	ghc.PopulatedPullRequests[0].Comments = []github.IssueComment{
+		{
+			Body:      "it seems some tests failed for the submission",
+			User:      github.User{Login: "cncfci(bot)"},
+			CreatedAt: time.Now().Add(-time.Hour * 24 * 30),
+		},
+	}
+	if err := checkStaleSubmission(context.TODO(), log, ghc, pullRequestQuery); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if comments := ghc.PopulatedPullRequests[0].Comments; len(comments) != 2 {
+		t.Fatalf("error: expected a reminder comment to be posted; got %v comment(s)", len(comments))
+	} else if !strings.Contains(comments[1].Body, "failing its checks for over 14 days") {
+		t.Fatalf("error: unexpected reminder comment: %v", comments[1].Body)
+	}
+	foundReminderLabel := false
+	for _, l := range pullRequestQuery.Labels.Nodes {
+		if string(l.Name) == staleReminderLabel {
+			foundReminderLabel = true
+		}
+	}
+	if !foundReminderLabel {
+		t.Fatalf("error: expected the %v label to be added", staleReminderLabel)
+	}
+	// a second sweep finds the reminder label and must not post again
+	if err := checkStaleSubmission(context.TODO(), log, ghc, pullRequestQuery); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if comments := ghc.PopulatedPullRequests[0].Comments; len(comments) != 2 {
+		t.Fatalf("error: expected exactly one reminder; got %v comment(s)", len(comments))
 	}
 }
 
-func Test_updateComments(t *testing.T) {
-	type args struct {
-		log     *logrus.Entry
-		ghc     githubClient
-		pr      *suite.PullRequestQuery
-		prSuite *suite.PRSuite
-		comment string
+func Test_checkStaleSubmissionSkipsFreshPR(t *testing.T) {
+	pullRequestQuery := &suite.PullRequestQuery{
+		Title:  githubql.String("Conformance results for v1.30/coolkube"),
+		Number: githubql.Int(1),
 	}
-	tests := []struct {
-		name    string
-		args    args
-		wantErr bool
-	}{
-		// TODO: Add test cases.
+	pullRequestQuery.Labels.Nodes = []struct{ Name githubql.String }{
+		{Name: githubql.String("failed-tests-present")},
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if err := updateComments(tt.args.log, tt.args.ghc, tt.args.pr, tt.args.prSuite, tt.args.comment); (err != nil) != tt.wantErr {
-				t.Errorf("updateComments() error = %v, wantErr %v", err, tt.wantErr)
-			}
-		})
+	ghc := &botAwareFakeGitHubClient{NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+		},
+	})}
+	ghc.PopulatedPullRequests[0].Comments = []github.IssueComment{
+		{
+			Body:      "it seems some tests failed for the submission",
+			User:      github.User{Login: "cncfci(bot)"},
+			CreatedAt: time.Now().Add(-time.Hour),
+		},
+	}
+	if err := checkStaleSubmission(context.TODO(), log, ghc, pullRequestQuery); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if comments := ghc.PopulatedPullRequests[0].Comments; len(comments) != 1 {
+		t.Fatalf("error: expected no reminder for a recently failing PR; got %v comment(s)", len(comments))
 	}
 }
 
@@ -1729,6 +6117,217 @@ func Test_isConformancePR(t *testing.T) {
 	}
 }
 
+func Test_matchConformancePRTitle(t *testing.T) {
+	type testCase struct {
+		name  string
+		title string
+		want  ConformancePRTitleMatch
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:  "standard phrasing with slash",
+			title: "Conformance results for v1.27/coolkube",
+			want: ConformancePRTitleMatch{
+				IsConformance: true,
+				Version:       "v1.27",
+				Product:       "coolkube",
+			},
+		},
+		{
+			name:  "standard phrasing with space",
+			title: "conformance results for v1.28 Cool Kubernetes Engine",
+			want: ConformancePRTitleMatch{
+				IsConformance: true,
+				Version:       "v1.28",
+				Product:       "Cool Kubernetes Engine",
+			},
+		},
+		{
+			name:  "colon separated variant",
+			title: "Conformance results: v1.29 / Acme",
+			want: ConformancePRTitleMatch{
+				IsConformance: true,
+				Version:       "v1.29",
+				Product:       "Acme",
+			},
+		},
+		{
+			name:  "test results variant",
+			title: "Conformance test results for v1.26 SuperStack",
+			want: ConformancePRTitleMatch{
+				IsConformance: true,
+				Version:       "v1.26",
+				Product:       "SuperStack",
+			},
+		},
+		{
+			name:  "singular result",
+			title: "conformance result for v1.30/coolkube",
+			want: ConformancePRTitleMatch{
+				IsConformance: true,
+				Version:       "v1.30",
+				Product:       "coolkube",
+			},
+		},
+		{
+			name:  "prefixed title",
+			title: "[cncf] Conformance results for v1.27/coolkube",
+			want: ConformancePRTitleMatch{
+				IsConformance: true,
+				Version:       "v1.27",
+				Product:       "coolkube",
+			},
+		},
+		{
+			name:  "missing version",
+			title: "Conformance results for coolkube",
+			want: ConformancePRTitleMatch{
+				IsConformance: true,
+				Product:       "coolkube",
+			},
+		},
+		{
+			name:  "unrelated title",
+			title: "cool soup recipe",
+			want:  ConformancePRTitleMatch{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := matchConformancePRTitle(tc.title)
+			if got != tc.want {
+				t.Fatalf("error: matchConformancePRTitle(%v) = %+v, want %+v", tc.title, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSetConformancePRTitlePattern(t *testing.T) {
+	defaultPattern := conformancePRTitlePattern
+	defer func() {
+		conformancePRTitlePattern = defaultPattern
+	}()
+
+	if err := SetConformancePRTitlePattern(`(?i)^results for (?P<version>v[0-9]+\.[0-9]+) \((?P<product>.*)\)$`); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	got := matchConformancePRTitle("Results for v1.29 (Acme)")
+	want := ConformancePRTitleMatch{
+		IsConformance: true,
+		Version:       "v1.29",
+		Product:       "Acme",
+	}
+	if got != want {
+		t.Fatalf("error: custom pattern match = %+v, want %+v", got, want)
+	}
+	if matchConformancePRTitle("Conformance results for v1.29/Acme").IsConformance {
+		t.Fatalf("error: expected custom pattern to replace the default")
+	}
+
+	if err := SetConformancePRTitlePattern(""); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if !matchConformancePRTitle("Results for v1.29 (Acme)").IsConformance {
+		t.Fatalf("error: expected empty pattern to leave the current value")
+	}
+
+	if err := SetConformancePRTitlePattern("(unterminated"); err == nil {
+		t.Fatalf("error: expected an error for an invalid pattern")
+	}
+}
+
+func TestParseVersionFromTitle(t *testing.T) {
+	type testCase struct {
+		name        string
+		title       string
+		want        string
+		expectedErr string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:  "version with slash separator",
+			title: "Conformance results for v1.27/coolkube",
+			want:  "v1.27",
+		},
+		{
+			name:  "version with space separator",
+			title: "Conformance results for v1.28 coolkube",
+			want:  "v1.28",
+		},
+		{
+			name:  "version without v prefix",
+			title: "Conformance results for 1.29/coolkube",
+			want:  "v1.29",
+		},
+		{
+			name:  "version with patch suffix",
+			title: "Conformance results for v1.29.0/coolkube",
+			want:  "v1.29",
+		},
+		{
+			name:  "version at the end of the title",
+			title: "coolkube conformance results v1.30",
+			want:  "v1.30",
+		},
+		{
+			name:  "version at the start of the title",
+			title: "v1.26 conformance results for coolkube",
+			want:  "v1.26",
+		},
+		{
+			name:  "colon separated variant",
+			title: "Conformance results: v1.29 / Acme",
+			want:  "v1.29",
+		},
+		{
+			name:  "same version repeated",
+			title: "Conformance results for v1.29 (tested against v1.29.2)",
+			want:  "v1.29",
+		},
+		{
+			name:  "product name containing a version",
+			title: "Conformance results for v1.27/coolkube 2.0",
+			want:  "",
+			expectedErr: "title 'Conformance results for v1.27/coolkube 2.0' " +
+				"declares multiple distinct release versions ('v1.27' and 'v2.0')",
+		},
+		{
+			name:  "two distinct versions",
+			title: "Conformance results for v1.28 and v1.29",
+			want:  "",
+			expectedErr: "title 'Conformance results for v1.28 and v1.29' " +
+				"declares multiple distinct release versions ('v1.28' and 'v1.29')",
+		},
+		{
+			name:        "no version",
+			title:       "Conformance results for coolkube",
+			want:        "",
+			expectedErr: "unable to find a release version in title 'Conformance results for coolkube'",
+		},
+		{
+			name:        "empty title",
+			title:       "",
+			want:        "",
+			expectedErr: "unable to find a release version in title ''",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseVersionFromTitle(tc.title)
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("error: %v", err)
+				}
+			} else if err == nil || err.Error() != tc.expectedErr {
+				t.Fatalf("error: unexpected error: want = %v; got = %v", tc.expectedErr, err)
+			}
+			if got != tc.want {
+				t.Fatalf("error: ParseVersionFromTitle(%v) = %v, want %v", tc.title, got, tc.want)
+			}
+		})
+	}
+}
+
 func Test_updateStatus(t *testing.T) {
 	type args struct {
 		log     *logrus.Entry
@@ -1877,3 +6476,248 @@ func Test_updateStatus(t *testing.T) {
 		})
 	}
 }
+
+func Test_updateStatusFailure(t *testing.T) {
+	pullRequestQuery := &suite.PullRequestQuery{
+		Number:     githubql.Int(1),
+		Title:      githubql.String("Conformance results for v1.30/coolkube"),
+		HeadRefOID: githubql.String("12345678"),
+		Repository: struct {
+			Name  githubql.String
+			Owner struct{ Login githubql.String }
+		}{
+			Name: githubql.String("k8s-conformance"),
+			Owner: struct{ Login githubql.String }{
+				Login: githubql.String("cncf"),
+			},
+		},
+	}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+		},
+	})
+	prSuite := suite.NewPRSuite(&suite.PullRequest{PullRequestQuery: *pullRequestQuery})
+	if err := updateStatus(log, ghc, pullRequestQuery, prSuite, "failure"); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	status := ghc.GetPopulatedPullRequests()[0].Status
+	if status.State != "failure" {
+		t.Fatalf("error: unexpected status state: %v", status.State)
+	}
+	if status.Description == "" {
+		t.Fatalf("error: expected the status description to be populated")
+	}
+	if expected := "https://github.com/cncf/k8s-conformance/pull/1"; status.TargetURL != expected {
+		t.Fatalf("error: unexpected status target URL: want = %v; got = %v", expected, status.TargetURL)
+	}
+}
+
+func Test_updateStatusDescriptions(t *testing.T) {
+	type testCase struct {
+		State               string
+		ExpectedDescription string
+	}
+	for _, tc := range []testCase{
+		{
+			State:               "success",
+			ExpectedDescription: "All checks are passing",
+		},
+		{
+			State:               "failure",
+			ExpectedDescription: "Please check failing requirements and update accordingly",
+		},
+		{
+			State:               "pending",
+			ExpectedDescription: "Verification in progress / awaiting corrections",
+		},
+		{
+			State:               "error",
+			ExpectedDescription: "The submission could not be evaluated; see the PR comment for details",
+		},
+		{
+			State:               "bogus",
+			ExpectedDescription: "Internal error",
+		},
+	} {
+		pullRequestQuery := &suite.PullRequestQuery{
+			Number:     githubql.Int(1),
+			Title:      githubql.String("Conformance results for v1.30/coolkube"),
+			HeadRefOID: githubql.String("12345678"),
+			Repository: struct {
+				Name  githubql.String
+				Owner struct{ Login githubql.String }
+			}{
+				Name: githubql.String("k8s-conformance"),
+				Owner: struct{ Login githubql.String }{
+					Login: githubql.String("cncf"),
+				},
+			},
+		}
+		ghc := NewFakeGitHubClient([]*prContext{
+			{
+				PullRequestQuery: pullRequestQuery,
+			},
+		})
+		prSuite := suite.NewPRSuite(&suite.PullRequest{PullRequestQuery: *pullRequestQuery})
+		if err := updateStatus(log, ghc, pullRequestQuery, prSuite, tc.State); err != nil {
+			t.Fatalf("error: in state '%v'; %v", tc.State, err)
+		}
+		status := ghc.GetPopulatedPullRequests()[0].Status
+		if status.State != tc.State {
+			t.Fatalf("error: unexpected status state: want = %v; got = %v", tc.State, status.State)
+		}
+		if status.Description != tc.ExpectedDescription {
+			t.Fatalf("error: unexpected status description for state '%v': want = %v; got = %v", tc.State, tc.ExpectedDescription, status.Description)
+		}
+		// setting the same state again on the same head is a no-op
+		if err := updateStatus(log, ghc, pullRequestQuery, prSuite, tc.State); err != nil {
+			t.Fatalf("error: in state '%v'; %v", tc.State, err)
+		}
+		if statuses := ghc.GetPopulatedPullRequests()[0].Statuses; len(statuses) != 1 {
+			t.Fatalf("error: expected one status for state '%v'; got %v", tc.State, len(statuses))
+		}
+	}
+}
+
+func Test_updateStatusHeadBeyondCommitList(t *testing.T) {
+	type commitNode = struct {
+		Commit struct {
+			Oid    githubql.String
+			Status struct {
+				Contexts []struct {
+					Context githubql.String
+					State   githubql.String
+				}
+			}
+		}
+	}
+	// a 7-commit PR; the query caps the commit list at 5, so the head commit
+	// is not among the listed ones
+	newQuery := func() *suite.PullRequestQuery {
+		pullRequestQuery := &suite.PullRequestQuery{
+			Number:     githubql.Int(1),
+			Title:      githubql.String("Conformance results for v1.30/coolkube"),
+			HeadRefOID: githubql.String("commit7"),
+			Repository: struct {
+				Name  githubql.String
+				Owner struct{ Login githubql.String }
+			}{
+				Name: githubql.String("k8s-conformance"),
+				Owner: struct{ Login githubql.String }{
+					Login: githubql.String("cncf"),
+				},
+			},
+		}
+		nodes := []commitNode{}
+		for i := 1; i <= 5; i++ {
+			node := commitNode{}
+			node.Commit.Oid = githubql.String(fmt.Sprintf("commit%v", i))
+			nodes = append(nodes, node)
+		}
+		pullRequestQuery.Commits.Nodes = nodes
+		return pullRequestQuery
+	}
+
+	// the head already carries a successful verify-conformance status, found
+	// through the combined status rather than the capped commit list
+	pullRequestQuery := newQuery()
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+			HeadRefOID:       "commit7",
+			Statuses: []github.Status{
+				{Context: "verify-conformance", State: "success"},
+			},
+		},
+	})
+	prSuite := suite.NewPRSuite(&suite.PullRequest{PullRequestQuery: *pullRequestQuery})
+	if err := updateStatus(log, ghc, pullRequestQuery, prSuite, "success"); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if statuses := ghc.GetPopulatedPullRequests()[0].Statuses; len(statuses) != 1 {
+		t.Fatalf("error: expected the up-to-date status to be left alone; got %v status(es)", len(statuses))
+	}
+
+	// with no status on the head, a new one is created for the true head
+	pullRequestQuery = newQuery()
+	ghc = NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+		},
+	})
+	prSuite = suite.NewPRSuite(&suite.PullRequest{PullRequestQuery: *pullRequestQuery})
+	if err := updateStatus(log, ghc, pullRequestQuery, prSuite, "failure"); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	pr := ghc.GetPopulatedPullRequests()[0]
+	if pr.Status.State != "failure" {
+		t.Fatalf("error: unexpected status state: %v", pr.Status.State)
+	}
+	if pr.HeadRefOID != "commit7" {
+		t.Fatalf("error: the status must be set on the true head; got '%v'", pr.HeadRefOID)
+	}
+}
+
+func Test_updateStatusForcePush(t *testing.T) {
+	type commitNode = struct {
+		Commit struct {
+			Oid    githubql.String
+			Status struct {
+				Contexts []struct {
+					Context githubql.String
+					State   githubql.String
+				}
+			}
+		}
+	}
+	// the branch was force-pushed: the commit list still carries the orphaned
+	// old head with its successful status, while HeadRefOID points at the new
+	// head, which has no status yet
+	pullRequestQuery := &suite.PullRequestQuery{
+		Number:     githubql.Int(1),
+		Title:      githubql.String("Conformance results for v1.30/coolkube"),
+		HeadRefOID: githubql.String("new-head"),
+		Repository: struct {
+			Name  githubql.String
+			Owner struct{ Login githubql.String }
+		}{
+			Name: githubql.String("k8s-conformance"),
+			Owner: struct{ Login githubql.String }{
+				Login: githubql.String("cncf"),
+			},
+		},
+	}
+	node := commitNode{}
+	node.Commit.Oid = githubql.String("old-head")
+	node.Commit.Status.Contexts = []struct {
+		Context githubql.String
+		State   githubql.String
+	}{
+		{
+			Context: githubql.String("verify-conformance"),
+			State:   githubql.String("SUCCESS"),
+		},
+	}
+	pullRequestQuery.Commits.Nodes = []commitNode{node}
+	ghc := NewFakeGitHubClient([]*prContext{
+		{
+			PullRequestQuery: pullRequestQuery,
+			HeadRefOID:       "old-head",
+			Statuses: []github.Status{
+				{Context: "verify-conformance", State: "success"},
+			},
+		},
+	})
+	prSuite := suite.NewPRSuite(&suite.PullRequest{PullRequestQuery: *pullRequestQuery})
+	if err := updateStatus(log, ghc, pullRequestQuery, prSuite, "pending"); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	pr := ghc.GetPopulatedPullRequests()[0]
+	if pr.Status.State != "pending" {
+		t.Fatalf("error: the stale success on the orphaned head must not suppress a new status; got state '%v'", pr.Status.State)
+	}
+	if pr.HeadRefOID != "new-head" {
+		t.Fatalf("error: the status must be set on the new head; got '%v'", pr.HeadRefOID)
+	}
+}
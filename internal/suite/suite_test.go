@@ -18,7 +18,11 @@ package suite
 
 import (
 	"bytes"
+	"crypto/sha256"
 	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"log"
 	"os"
 	"path"
@@ -173,6 +177,83 @@ func TestNewTestSuite(t *testing.T) {
 	}
 }
 
+func TestNewTestSuiteTagFilter(t *testing.T) {
+	prSuite := NewPRSuite(&PullRequest{
+		PullRequestQuery: PullRequestQuery{
+			Title: githubql.String("Conformance results for v1.30/coolkube"),
+			Commits: struct {
+				Nodes []struct {
+					Commit struct {
+						Oid    githubql.String
+						Status struct {
+							Contexts []struct {
+								Context githubql.String
+								State   githubql.String
+							}
+						}
+					}
+				}
+			}{
+				Nodes: []struct {
+					Commit struct {
+						Oid    githubql.String
+						Status struct {
+							Contexts []struct {
+								Context githubql.String
+								State   githubql.String
+							}
+						}
+					}
+				}{
+					{
+						Commit: struct {
+							Oid    githubql.String
+							Status struct {
+								Contexts []struct {
+									Context githubql.String
+									State   githubql.String
+								}
+							}
+						}{
+							Oid: githubql.String(""),
+							Status: struct {
+								Contexts []struct {
+									Context githubql.String
+									State   githubql.String
+								}
+							}{
+								Contexts: []struct {
+									Context githubql.String
+									State   githubql.String
+								}{
+									{
+										Context: githubql.String(""),
+										State:   githubql.String(""),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	prSuite.KubernetesReleaseVersion = "v1.30"
+	prSuite.KubernetesReleaseVersionLatest = "v1.31"
+	prSuite.NewTestSuite(PRSuiteOptions{Paths: []string{"../../kodata/features/verify-conformance.feature"}, Tags: "@commits"}).Run()
+	comment, _, state, err := prSuite.GetLabelsAndCommentsFromSuiteResultsBuffer()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	expectedComment := "All requirements (1) have passed for the submission!\n\nOnly scenarios matching the tag filter '@commits' were run; requirements outside the filter have not been checked.\n"
+	if comment != expectedComment {
+		t.Fatalf("error: comment '%v' does not match expected '%v'", comment, expectedComment)
+	}
+	if state != "pending" {
+		t.Fatalf("error: a tag-filtered run must not claim state 'success' for unrun requirements; got '%v'", state)
+	}
+}
+
 func TestSetMetadataFolder(t *testing.T) {
 	newMetadataFolder := "abc/123/cool/test/path"
 	prSuite := NewPRSuite(&PullRequest{})
@@ -327,6 +408,129 @@ func TestIsIncludedInItsFileList(t *testing.T) {
 	}
 }
 
+func Test_fileNameIsNearMiss(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Submitted string
+		Required  string
+		Expected  bool
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:      "wrong extension",
+			Submitted: "e2e.txt",
+			Required:  "e2e.log",
+			Expected:  true,
+		},
+		{
+			Name:      "wrong separator",
+			Submitted: "junit-01.xml",
+			Required:  "junit_01.xml",
+			Expected:  true,
+		},
+		{
+			Name:      "missing numbering",
+			Submitted: "junit.xml",
+			Required:  "junit_01.xml",
+			Expected:  true,
+		},
+		{
+			Name:      "exact match is not a near miss",
+			Submitted: "e2e.log",
+			Required:  "e2e.log",
+		},
+		{
+			Name:      "case difference is not a near miss",
+			Submitted: "E2E.log",
+			Required:  "e2e.log",
+		},
+		{
+			Name:      "unrelated file",
+			Submitted: "main.go",
+			Required:  "e2e.log",
+		},
+		{
+			Name:      "further numbered junit file",
+			Submitted: "junit_02.xml",
+			Required:  "junit_01.xml",
+		},
+	} {
+		if got := fileNameIsNearMiss(tc.Submitted, tc.Required); got != tc.Expected {
+			t.Fatalf("error: testcase '%v' fileNameIsNearMiss(%v, %v) = %v, want %v", tc.Name, tc.Submitted, tc.Required, got, tc.Expected)
+		}
+	}
+}
+
+func TestIsIncludedInItsFileListNearMiss(t *testing.T) {
+	prSuite := NewPRSuite(&PullRequest{
+		SupportingFiles: []*PullRequestFile{
+			{
+				Name:     "v1.30/coolkube/e2e.txt",
+				BaseName: "e2e.txt",
+			},
+		},
+	})
+	err := prSuite.isIncludedInItsFileList("e2e.log")
+	if err == nil {
+		t.Fatalf("error: the canonical file name check must stay authoritative")
+	}
+	expectedError := "missing file &#39;e2e.log&#39;; found &#39;e2e.txt&#39;, please rename it to &#39;e2e.log&#39;"
+	if err.Error() != expectedError {
+		t.Fatalf("error: unexpected error: want = %v; got = %v", expectedError, err.Error())
+	}
+	foundLabel := false
+	for _, l := range prSuite.Labels {
+		if l == "missing-file-e2e.log" {
+			foundLabel = true
+		}
+	}
+	if !foundLabel {
+		t.Fatalf("error: expected the missing-file-e2e.log label")
+	}
+}
+
+func TestIsIncludedInItsFileListREADME(t *testing.T) {
+	for _, baseName := range []string{"README.md", "readme.md"} {
+		prSuite := NewPRSuite(&PullRequest{
+			SupportingFiles: []*PullRequestFile{
+				{
+					Name:     "v1.30/coolkube/" + baseName,
+					BaseName: baseName,
+				},
+			},
+		})
+		if err := prSuite.isIncludedInItsFileList("README.md"); err != nil {
+			t.Fatalf("error: '%v' must satisfy the README.md requirement: %v", baseName, err)
+		}
+	}
+	prSuite := NewPRSuite(&PullRequest{
+		SupportingFiles: []*PullRequestFile{
+			{
+				Name:     "v1.30/coolkube/PRODUCT.yaml",
+				BaseName: "PRODUCT.yaml",
+			},
+		},
+	})
+	err := prSuite.isIncludedInItsFileList("README.md")
+	if err == nil {
+		t.Fatalf("error: a submission without a README must fail the check")
+	}
+	expectedError := "missing file &#39;README.md&#39;"
+	if err.Error() != expectedError {
+		t.Fatalf("error: unexpected error: want = %v; got = %v", expectedError, err.Error())
+	}
+	foundLabel := false
+	for _, l := range prSuite.Labels {
+		if l == "missing-file-README.md" {
+			foundLabel = true
+		}
+	}
+	if !foundLabel {
+		t.Fatalf("error: expected the missing-file-README.md label")
+	}
+}
+
 func TestFileFolderStructureMatchesRegex(t *testing.T) {
 	type testSuite struct {
 		Name                string
@@ -418,6 +622,104 @@ func TestFileFolderStructureMatchesRegex(t *testing.T) {
 	}
 }
 
+func TestTheSubmissionDirectoryStructureIsValid(t *testing.T) {
+	type testCase struct {
+		Name                string
+		PullRequest         *PullRequest
+		ExpectedErrorString string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name: "valid directory structure",
+			PullRequest: &PullRequest{
+				PullRequestQuery: PullRequestQuery{
+					Title: githubql.String("Conformance results for v1.30/coolkube"),
+				},
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name: "v1.30/coolkube/README.md",
+					},
+					{
+						Name: "v1.30/coolkube/PRODUCT.yaml",
+					},
+				},
+			},
+		},
+		{
+			Name: "invalid with a file at the repository root",
+			PullRequest: &PullRequest{
+				PullRequestQuery: PullRequestQuery{
+					Title: githubql.String("Conformance results for v1.30/coolkube"),
+				},
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name: "v1.30/coolkube/README.md",
+					},
+					{
+						Name: "PRODUCT.yaml",
+					},
+				},
+			},
+			ExpectedErrorString: "found files in: the repository root",
+		},
+		{
+			Name: "invalid with a version directory not matching the title",
+			PullRequest: &PullRequest{
+				PullRequestQuery: PullRequestQuery{
+					Title: githubql.String("Conformance results for v1.30/coolkube"),
+				},
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name: "v1.29/coolkube/README.md",
+					},
+					{
+						Name: "v1.29/coolkube/PRODUCT.yaml",
+					},
+				},
+			},
+			ExpectedErrorString: "doesn&#39;t match the release version in the title (v1.30)",
+		},
+		{
+			Name: "invalid with files across two product directories",
+			PullRequest: &PullRequest{
+				PullRequestQuery: PullRequestQuery{
+					Title: githubql.String("Conformance results for v1.30/coolkube"),
+				},
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name: "v1.30/coolkube/README.md",
+					},
+					{
+						Name: "v1.30/coolkube-enterprise/README.md",
+					},
+				},
+			},
+			ExpectedErrorString: "2 different product directories",
+		},
+	} {
+		prSuite := NewPRSuite(tc.PullRequest)
+		err := prSuite.theSubmissionDirectoryStructureIsValid()
+		if tc.ExpectedErrorString == "" && err != nil {
+			t.Fatalf("error with testcase '%v'; %v", tc.Name, err)
+		}
+		if tc.ExpectedErrorString != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedErrorString) {
+				t.Fatalf("error with testcase '%v'; expected error containing '%v', got '%v'", tc.Name, tc.ExpectedErrorString, err)
+			}
+			foundLabel := false
+			for _, l := range prSuite.Labels {
+				if l == "unable-to-process" {
+					foundLabel = true
+				}
+			}
+			if !foundLabel {
+				t.Fatalf("error with testcase '%v'; expected the unable-to-process label", tc.Name)
+			}
+		}
+	}
+}
+
 func TestThereIsOnlyOnePathOfFolders(t *testing.T) {
 	type testSuite struct {
 		Name                string
@@ -670,9 +972,55 @@ func TestTheFilesIncludedInThePRAreOnly(t *testing.T) {
 			FilesString:         "README.md, e2e.log, PRODUCT.yaml, junit_01.xml",
 			ExpectedErrorString: "it appears that there are 3 non-required file(s) included in the submission: scenic-photo.png, soup-recommendation.ogg, caleb-was-here.txt",
 		},
+		{
+			Name: "valid submission with a checksum manifest",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{
+						BaseName: "README.md",
+					},
+					{
+						BaseName: "e2e.log",
+					},
+					{
+						BaseName: "PRODUCT.yaml",
+					},
+					{
+						BaseName: "junit_01.xml",
+					},
+					{
+						BaseName: "SHA256SUMS",
+					},
+				},
+			},
+			FilesString: "README.md, e2e.log, PRODUCT.yaml, junit_01.xml",
+		},
+		{
+			Name: "valid submission with a lowercase readme",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{
+						BaseName: "readme.md",
+					},
+					{
+						BaseName: "e2e.log",
+					},
+					{
+						BaseName: "PRODUCT.yaml",
+					},
+					{
+						BaseName: "junit_01.xml",
+					},
+				},
+			},
+			FilesString: "README.md, e2e.log, PRODUCT.yaml, junit_01.xml",
+		},
 	} {
 		prSuite := NewPRSuite(tc.PullRequest)
 		err := prSuite.theFilesIncludedInThePRAreOnly(tc.FilesString)
+		if tc.ExpectedErrorString == "" && err != nil {
+			t.Errorf("unexpected error in testcase (%v): %v", tc.Name, err)
+		}
 		if err != nil && !strings.Contains(err.Error(), tc.ExpectedErrorString) {
 			t.Errorf("unexpected error in testcase (%v): %v", tc.Name, err)
 		}
@@ -699,6 +1047,27 @@ func TestAFile(t *testing.T) {
 	if err := prSuite.aFile("README.md"); err != nil && !strings.Contains(err.Error(), "missing required file") {
 		t.Fatalf("error expected missing file 'README.md'; %v", err)
 	}
+
+	correctionSuite := NewPRSuite(&PullRequest{
+		PullRequestQuery: PullRequestQuery{
+			Title: githubql.String("Conformance results for v1.30/coolkube"),
+		},
+		SupportingFiles: []*PullRequestFile{
+			{
+				Name:     "v1.30/coolkube/PRODUCT.yaml",
+				BaseName: "PRODUCT.yaml",
+				Contents: "vendor: cool",
+				Status:   "modified",
+			},
+		},
+		IsCorrection: true,
+	})
+	if err := correctionSuite.aFile("e2e.log"); err != nil {
+		t.Fatalf("error: a correction PR must not require files it doesn't change; %v", err)
+	}
+	if err := correctionSuite.isNotEmpty("junit_01.xml"); err != nil {
+		t.Fatalf("error: a correction PR must not require files it doesn't change; %v", err)
+	}
 }
 
 func TestGetFileByFileName(t *testing.T) {
@@ -784,25 +1153,64 @@ contact_email_address: "sales@coolkubernetes.com"`,
 			ExpectedErrorString: "missing or empty field",
 		},
 		{
-			Name:                "missing PRODUCT.yaml",
-			ExpectedErrorString: "missing required file",
-			PullRequest:         &PullRequest{},
+			Name: "invalid PRODUCT.yaml missing vendor",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name:     "v1.30/coolkube/PRODUCT.yaml",
+						BaseName: "PRODUCT.yaml",
+						Contents: `name: "coolkube"
+version: "v1.30"
+type: "distribution"
+description: "it's just all-round cool and probably the best k8s, idk"
+website_url: "https://coolkubernetes.com"
+documentation_url: "https://coolkubernetes.com/docs"
+contact_email_address: "sales@coolkubernetes.com"`,
+					},
+				},
+			},
+			ExpectedErrorString: "missing or empty field &#39;vendor&#39;",
 		},
 		{
-			Name:                "invalid PRODUCT.yaml unable to parse",
-			ExpectedErrorString: "unable to read file",
+			Name: "invalid PRODUCT.yaml blank version",
 			PullRequest: &PullRequest{
 				SupportingFiles: []*PullRequestFile{
 					{
 						Name:     "v1.30/coolkube/PRODUCT.yaml",
 						BaseName: "PRODUCT.yaml",
-						Contents: `v"`,
+						Contents: `vendor: "cool"
+name: "coolkube"
+version: ""
+type: "distribution"
+description: "it's just all-round cool and probably the best k8s, idk"
+website_url: "https://coolkubernetes.com"
+documentation_url: "https://coolkubernetes.com/docs"
+contact_email_address: "sales@coolkubernetes.com"`,
 					},
 				},
 			},
+			ExpectedErrorString: "missing or empty field &#39;version&#39;",
 		},
-	} {
-		prSuite := NewPRSuite(tc.PullRequest)
+		{
+			Name:                "missing PRODUCT.yaml",
+			ExpectedErrorString: "missing required file",
+			PullRequest:         &PullRequest{},
+		},
+		{
+			Name:                "invalid PRODUCT.yaml unable to parse",
+			ExpectedErrorString: "unable to read file",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name:     "v1.30/coolkube/PRODUCT.yaml",
+						BaseName: "PRODUCT.yaml",
+						Contents: `v"`,
+					},
+				},
+			},
+		},
+	} {
+		prSuite := NewPRSuite(tc.PullRequest)
 	k:
 		for _, k := range requiredKeys {
 			err := prSuite.theYamlFileContainsTheRequiredAndNonEmptyField("PRODUCT.yaml", k)
@@ -1405,6 +1813,114 @@ contact_email_address: "greetings@cool.kube"`
 	}
 }
 
+func TestTheContentOfTheUrlInTheValueOfMatchesStatusCodes(t *testing.T) {
+	type testCase struct {
+		Name                string
+		StatusCode          int
+		ExpectedErrorString string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:       "url resolving to 200 passes",
+			StatusCode: 200,
+		},
+		{
+			Name:                "url resolving to 404 fails",
+			StatusCode:          404,
+			ExpectedErrorString: "URL field &#39;website_url&#39; in PRODUCT.yaml returned status 404",
+		},
+		{
+			Name:                "url resolving to 500 fails",
+			StatusCode:          500,
+			ExpectedErrorString: "URL field &#39;website_url&#39; in PRODUCT.yaml returned status 500",
+		},
+	} {
+		prSuite := NewPRSuite(&PullRequest{})
+		prSuite.PR.ProductYAMLURLDataTypes = map[string]string{
+			"website_url": "text/html",
+		}
+		prSuite.PR.ProductYAMLURLStatusCodes = map[string]int{
+			"website_url": tc.StatusCode,
+		}
+		err := prSuite.theContentOfTheUrlInTheValueOfMatches("website_url", "text/html")
+		if err != nil && tc.ExpectedErrorString == "" {
+			t.Fatalf("error: testcase (%v) unexpected error: %v", tc.Name, err)
+		}
+		if err == nil && tc.ExpectedErrorString != "" {
+			t.Fatalf("error: testcase (%v) expected an error containing '%v'", tc.Name, tc.ExpectedErrorString)
+		}
+		if err != nil && !strings.Contains(err.Error(), tc.ExpectedErrorString) {
+			t.Fatalf("error: testcase (%v) error (%v) doesn't contain expected string (%v)", tc.Name, err, tc.ExpectedErrorString)
+		}
+	}
+}
+
+func TestTheProductLogoIsAnSVGImage(t *testing.T) {
+	type testCase struct {
+		Name                string
+		ContentType         string
+		ProductLogoContent  string
+		ExpectedErrorString string
+		ExpectedLabels      []string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:               "valid svg logo",
+			ContentType:        "image/svg+xml",
+			ProductLogoContent: `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 100 100"><circle cx="50" cy="50" r="40"/></svg>`,
+		},
+		{
+			Name: "no logo url resolved",
+		},
+		{
+			Name:                "logo resolves to a png",
+			ContentType:         "image/png",
+			ProductLogoContent:  "\x89PNG\r\n\x1a\n",
+			ExpectedErrorString: "must be (image/svg+xml)",
+			ExpectedLabels:      []string{"conformance-product-submission", "invalid-product-logo"},
+		},
+		{
+			Name:                "png content masquerading as an svg",
+			ContentType:         "image/svg+xml",
+			ProductLogoContent:  "\x89PNG\r\n\x1a\n",
+			ExpectedErrorString: "doesn&#39;t appear to resolve to an SVG image",
+			ExpectedLabels:      []string{"conformance-product-submission", "invalid-product-logo"},
+		},
+		{
+			Name:                "svg with an extreme aspect ratio",
+			ContentType:         "image/svg+xml",
+			ProductLogoContent:  `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 1000 10"></svg>`,
+			ExpectedErrorString: "extreme aspect ratio",
+			ExpectedLabels:      []string{"conformance-product-submission", "invalid-product-logo"},
+		},
+		{
+			Name:               "svg sized with width and height attributes",
+			ContentType:        "image/svg+xml",
+			ProductLogoContent: `<svg xmlns="http://www.w3.org/2000/svg" width="100px" height="50px"></svg>`,
+		},
+	} {
+		prSuite := NewPRSuite(&PullRequest{})
+		if tc.ContentType != "" {
+			prSuite.PR.ProductYAMLURLDataTypes = map[string]string{
+				"product_logo_url": tc.ContentType,
+			}
+		}
+		prSuite.PR.ProductLogoContent = tc.ProductLogoContent
+		err := prSuite.theProductLogoIsAnSVGImage()
+		if err != nil && tc.ExpectedErrorString == "" {
+			t.Fatalf("error with testcase '%v'; %v", tc.Name, err)
+		}
+		if tc.ExpectedErrorString != "" && (err == nil || !strings.Contains(err.Error(), tc.ExpectedErrorString)) {
+			t.Fatalf("error with testcase '%v'; expected error containing '%v', got '%v'", tc.Name, tc.ExpectedErrorString, err)
+		}
+		if len(tc.ExpectedLabels) > 0 && !reflect.DeepEqual(prSuite.Labels, tc.ExpectedLabels) {
+			t.Fatalf("error with testcase '%v'; labels (%v) don't match expected labels (%v)", tc.Name, prSuite.Labels, tc.ExpectedLabels)
+		}
+	}
+}
+
 func TestTheTypeFieldInPRODUCTyamlIsValid(t *testing.T) {
 	type testCase struct {
 		Name          string
@@ -1561,6 +2077,7 @@ func TestSetSubmissionMetadatafromFolderStructure(t *testing.T) {
 func TestTheReleaseVersionMatchesTheReleaseVersionInTheTitle(t *testing.T) {
 	type testCase struct {
 		PullRequest         *PullRequest
+		ExpectedPrerelease  string
 		ExpectedErrorString string
 	}
 
@@ -1580,12 +2097,201 @@ func TestTheReleaseVersionMatchesTheReleaseVersionInTheTitle(t *testing.T) {
 			},
 			ExpectedErrorString: "the Kubernetes release version in the title",
 		},
+		{
+			PullRequest: &PullRequest{
+				PullRequestQuery: PullRequestQuery{
+					Title: githubql.String("conformance results for v1.30.0-rc.1/coolkube"),
+				},
+			},
+			ExpectedPrerelease: "rc.1",
+		},
+		{
+			PullRequest: &PullRequest{
+				PullRequestQuery: PullRequestQuery{
+					Title: githubql.String("conformance results for v1.30.0-beta.0/coolkube"),
+				},
+			},
+			ExpectedPrerelease: "beta.0",
+		},
 	} {
 		prSuite := NewPRSuite(tc.PullRequest)
 		prSuite.KubernetesReleaseVersion = "v1.30"
 		if err := prSuite.theReleaseVersionMatchesTheReleaseVersionInTheTitle(); err != nil && !strings.Contains(err.Error(), tc.ExpectedErrorString) {
 			t.Fatalf("error unexpected error matching the release version in the title: %v", err)
 		}
+		if prSuite.KubernetesReleaseVersionPrerelease != tc.ExpectedPrerelease {
+			t.Fatalf("error: prerelease (%v) found in title (%v) doesn't match expected prerelease (%v)", prSuite.KubernetesReleaseVersionPrerelease, tc.PullRequest.Title, tc.ExpectedPrerelease)
+		}
+	}
+}
+
+func TestTheReleaseVersionInTheArtifactsMatchesTheReleaseVersionOfTheSubmission(t *testing.T) {
+	type testCase struct {
+		Name                     string
+		KubernetesReleaseVersion string
+		PullRequest              *PullRequest
+		ExpectedErrorString      string
+	}
+	for _, tc := range []testCase{
+		{
+			Name:                     "matching versions",
+			KubernetesReleaseVersion: "v1.29",
+			PullRequest: &PullRequest{
+				PullRequestQuery: PullRequestQuery{
+					Title: githubql.String("Conformance results for v1.29/coolkube"),
+				},
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name:     "v1.29/coolkube/e2e.log",
+						BaseName: "e2e.log",
+						Contents: `Server Version: version.Info{Major:"1", Minor:"29", GitVersion:"v1.29.1"}`,
+					},
+					{
+						Name:     "v1.29/coolkube/junit_01.xml",
+						BaseName: "junit_01.xml",
+						Contents: `<properties><property name="TestVersion" value="v1.29.1"></property></properties>`,
+					},
+				},
+			},
+		},
+		{
+			Name:                     "three way disagreement",
+			KubernetesReleaseVersion: "v1.29",
+			PullRequest: &PullRequest{
+				PullRequestQuery: PullRequestQuery{
+					Title: githubql.String("Conformance results for v1.29/coolkube"),
+				},
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name:     "v1.29/coolkube/e2e.log",
+						BaseName: "e2e.log",
+						Contents: `Server Version: version.Info{Major:"1", Minor:"28", GitVersion:"v1.28.3"}`,
+					},
+					{
+						Name:     "v1.29/coolkube/junit_01.xml",
+						BaseName: "junit_01.xml",
+						Contents: `<properties><property name="TestVersion" value="v1.27.0"></property></properties>`,
+					},
+				},
+			},
+			ExpectedErrorString: "e2e.log reports v1.28, junit_01.xml reports v1.27",
+		},
+		{
+			Name:                     "no version detectable in artifacts",
+			KubernetesReleaseVersion: "v1.29",
+			PullRequest: &PullRequest{
+				PullRequestQuery: PullRequestQuery{
+					Title: githubql.String("Conformance results for v1.29/coolkube"),
+				},
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name:     "v1.29/coolkube/e2e.log",
+						BaseName: "e2e.log",
+						Contents: `ran some tests and all was well`,
+					},
+				},
+			},
+		},
+		{
+			Name:                     "matching conformance plugin image tag",
+			KubernetesReleaseVersion: "v1.29",
+			PullRequest: &PullRequest{
+				PullRequestQuery: PullRequestQuery{
+					Title: githubql.String("Conformance results for v1.29/coolkube"),
+				},
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name:     "v1.29/coolkube/e2e.log",
+						BaseName: "e2e.log",
+						Contents: `Running plugin with image registry.k8s.io/conformance:v1.29.0`,
+					},
+				},
+			},
+		},
+		{
+			Name:                     "mismatching conformance plugin image tag",
+			KubernetesReleaseVersion: "v1.29",
+			PullRequest: &PullRequest{
+				PullRequestQuery: PullRequestQuery{
+					Title: githubql.String("Conformance results for v1.29/coolkube"),
+				},
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name:     "v1.29/coolkube/e2e.log",
+						BaseName: "e2e.log",
+						Contents: `Running plugin with image k8s.gcr.io/conformance:v1.28.3`,
+					},
+				},
+			},
+			ExpectedErrorString: "the conformance plugin image in e2e.log reports v1.28",
+		},
+	} {
+		prSuite := NewPRSuite(tc.PullRequest)
+		prSuite.KubernetesReleaseVersion = tc.KubernetesReleaseVersion
+		err := prSuite.theReleaseVersionInTheArtifactsMatchesTheReleaseVersionOfTheSubmission()
+		if tc.ExpectedErrorString == "" && err != nil {
+			t.Fatalf("unexpected error in testcase '%v': %v", tc.Name, err)
+		}
+		if tc.ExpectedErrorString != "" {
+			if err == nil {
+				t.Fatalf("error: testcase '%v' expected to fail", tc.Name)
+			}
+			if !strings.Contains(err.Error(), tc.ExpectedErrorString) {
+				t.Fatalf("error: testcase '%v' error (%v) doesn't contain the expected string (%v)", tc.Name, err, tc.ExpectedErrorString)
+			}
+			foundLabel := false
+			for _, l := range prSuite.Labels {
+				if l == "version-mismatch" {
+					foundLabel = true
+				}
+			}
+			if !foundLabel {
+				t.Fatalf("error: testcase '%v' expected the version-mismatch label", tc.Name)
+			}
+		}
+	}
+}
+
+func TestGetE2eLogConformanceImageVersion(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Contents string
+		Expected string
+	}
+	for _, tc := range []testCase{
+		{
+			Name:     "registry qualified image",
+			Contents: `Running plugin with image registry.k8s.io/conformance:v1.30.2`,
+			Expected: "v1.30",
+		},
+		{
+			Name:     "unqualified image",
+			Contents: `image: conformance:v1.29.0`,
+			Expected: "v1.29",
+		},
+		{
+			Name:     "tag without a patch version",
+			Contents: `image: k8s.gcr.io/conformance:v1.28`,
+			Expected: "v1.28",
+		},
+		{
+			Name:     "no conformance image",
+			Contents: `ran some tests and all was well`,
+			Expected: "",
+		},
+	} {
+		prSuite := NewPRSuite(&PullRequest{
+			SupportingFiles: []*PullRequestFile{
+				{
+					Name:     "v1.30/coolkube/e2e.log",
+					BaseName: "e2e.log",
+					Contents: tc.Contents,
+				},
+			},
+		})
+		if version := prSuite.GetE2eLogConformanceImageVersion(); version != tc.Expected {
+			t.Fatalf("error: testcase '%v' detected version (%v) doesn't match expected version (%v)", tc.Name, version, tc.Expected)
+		}
 	}
 }
 
@@ -1618,10 +2324,13 @@ func TestTheReleaseVersion(t *testing.T) {
 
 func TestItIsAValidAndSupportedRelease(t *testing.T) {
 	type testCase struct {
-		Name                string
-		Version             string
-		VersionLatest       string
-		ExpectedErrorString string
+		Name                    string
+		Version                 string
+		VersionLatest           string
+		Prerelease              string
+		AcceptReleaseCandidates bool
+		ExpectedErrorString     string
+		ExpectedLabels          []string
 	}
 
 	for _, tc := range []testCase{
@@ -1630,12 +2339,40 @@ func TestItIsAValidAndSupportedRelease(t *testing.T) {
 			Version:       "v1.30",
 			VersionLatest: "v1.30.0",
 		},
+		{
+			Name:                "release candidate not accepted by default",
+			Version:             "v1.30",
+			VersionLatest:       "v1.30.0",
+			Prerelease:          "rc.1",
+			ExpectedErrorString: "pre-release versions are not accepted",
+		},
+		{
+			Name:                    "release candidate accepted when configured",
+			Version:                 "v1.30",
+			VersionLatest:           "v1.30.0",
+			Prerelease:              "rc.1",
+			AcceptReleaseCandidates: true,
+			ExpectedLabels:          []string{"conformance-product-submission", "release-candidate"},
+		},
 		{
 			Name:                "invalid unsupported release",
 			Version:             "v1.14",
 			VersionLatest:       "v1.30.0",
 			ExpectedErrorString: "unable to use version",
 		},
+		{
+			Name:                "end-of-life release is labelled",
+			Version:             "v1.20",
+			VersionLatest:       "v1.30.0",
+			ExpectedErrorString: "it has reached end-of-life",
+			ExpectedLabels:      []string{"conformance-product-submission", "eol-version"},
+		},
+		{
+			Name:           "current release is not labelled end-of-life",
+			Version:        "v1.29",
+			VersionLatest:  "v1.30.0",
+			ExpectedLabels: []string{"conformance-product-submission"},
+		},
 		{
 			Name:                "invalid future release",
 			Version:             "v1.208",
@@ -1658,57 +2395,222 @@ func TestItIsAValidAndSupportedRelease(t *testing.T) {
 		prSuite := NewPRSuite(&PullRequest{})
 		prSuite.KubernetesReleaseVersion = tc.Version
 		prSuite.KubernetesReleaseVersionLatest = tc.VersionLatest
-		if err := prSuite.itIsAValidAndSupportedRelease(); err != nil && !strings.Contains(err.Error(), tc.ExpectedErrorString) {
+		prSuite.KubernetesReleaseVersionPrerelease = tc.Prerelease
+		SetAcceptReleaseCandidates(tc.AcceptReleaseCandidates)
+		err := prSuite.itIsAValidAndSupportedRelease()
+		SetAcceptReleaseCandidates(false)
+		if err != nil && !strings.Contains(err.Error(), tc.ExpectedErrorString) {
 			t.Fatalf("error: unexpected error in test case '%v': %v", tc.Name, err)
 		}
+		if tc.Prerelease != "" && tc.ExpectedErrorString != "" && err == nil {
+			t.Fatalf("error: test case '%v' expected to fail", tc.Name)
+		}
+		if len(tc.ExpectedLabels) > 0 && !reflect.DeepEqual(prSuite.Labels, tc.ExpectedLabels) {
+			t.Fatalf("error: test case '%v' labels (%v) don't match expected labels (%v)", tc.Name, prSuite.Labels, tc.ExpectedLabels)
+		}
 	}
 }
 
-func TestGetRequiredTests(t *testing.T) {
+func TestTheE2eLogContainsASonobuoyRunSummary(t *testing.T) {
 	type testCase struct {
 		Name                string
-		Version             string
-		ExpectedTestsCount  int
+		PullRequest         *PullRequest
 		ExpectedErrorString string
-		MetadataFolder      *string
+		ExpectedSummary     *E2eLogSummary
 	}
-
 	for _, tc := range []testCase{
 		{
-			Name:               "valid",
-			Version:            "v1.30",
-			ExpectedTestsCount: 402, // NOTE magic number is count of tests form conformance.yaml
-		},
-		{
-			Name:               "valid alternate version",
-			Version:            "v1.29",
-			ExpectedTestsCount: 388, // NOTE magic number is count of tests form conformance.yaml
-		},
-		{
-			Name:               "valid with test with version above pr version",
-			Version:            "v1.31",
-			MetadataFolder:     common.Pointer("testdata/metadata/version-of-test-higher"),
-			ExpectedTestsCount: 0,
-		},
-		{
-			Name:                "invalid with malformed version",
-			Version:             "v1.notfound",
-			ExpectedTestsCount:  0,
-			ExpectedErrorString: "Malformed version",
+			Name: "genuine log with a run summary",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name:     "v1.30/coolkube/e2e.log",
+						BaseName: "e2e.log",
+						Contents: `I0501 14:20:30.000000      20 e2e.go:126] Starting e2e run
+Ran 406 of 7201 Specs in 6096.544 seconds
+SUCCESS! -- 406 Passed | 0 Failed | 0 Pending | 6795 Skipped`,
+					},
+				},
+			},
+			ExpectedSummary: &E2eLogSummary{Ran: 406, Total: 7201, Passed: 406, Failed: 0},
 		},
 		{
-			Name:                "invalid unable to parse conformance.yaml",
-			Version:             "v1.123",
-			ExpectedTestsCount:  0,
-			MetadataFolder:      common.Pointer("testdata/metadata/unable-to-parse"),
-			ExpectedErrorString: "cannot unmarshal string into Go value of type []suite.ConformanceTestMetadata",
+			Name: "log missing the run summary",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name:     "v1.30/coolkube/e2e.log",
+						BaseName: "e2e.log",
+						Contents: `I0501 14:20:30.000000      20 e2e.go:126] Starting e2e run`,
+					},
+				},
+			},
+			ExpectedErrorString: "doesn&#39;t appear to contain the summary of a Sonobuoy run",
 		},
 		{
-			Name:                "invalid version in conformance.yaml test",
-			Version:             "v1.27",
-			ExpectedTestsCount:  0,
-			MetadataFolder:      common.Pointer("testdata/metadata/bad-version-in-conformance.yaml"),
-			ExpectedErrorString: "Malformed version",
+			Name: "missing e2e.log",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{},
+			},
+			ExpectedErrorString: "unable to find file &#39;e2e.log&#39;",
+		},
+	} {
+		prSuite := NewPRSuite(tc.PullRequest)
+		err := prSuite.theE2eLogContainsASonobuoyRunSummary()
+		if tc.ExpectedErrorString == "" && err != nil {
+			t.Fatalf("error in testcase '%v': %v", tc.Name, err)
+		}
+		if tc.ExpectedErrorString != "" {
+			if err == nil {
+				t.Fatalf("error: testcase '%v' expected an error containing '%v'", tc.Name, tc.ExpectedErrorString)
+			}
+			if !strings.Contains(err.Error(), tc.ExpectedErrorString) {
+				t.Fatalf("error: testcase '%v' error (%v) doesn't contain the expected string (%v)", tc.Name, err, tc.ExpectedErrorString)
+			}
+			foundLabel := false
+			for _, l := range prSuite.Labels {
+				if l == "evidence-missing" {
+					foundLabel = true
+				}
+			}
+			if !foundLabel {
+				t.Fatalf("error: testcase '%v' expected the label 'evidence-missing' in (%v)", tc.Name, prSuite.Labels)
+			}
+		}
+		if tc.ExpectedSummary != nil {
+			if prSuite.E2eLogSummary == nil {
+				t.Fatalf("error: testcase '%v' expected the run summary to be set", tc.Name)
+			}
+			if *prSuite.E2eLogSummary != *tc.ExpectedSummary {
+				t.Fatalf("error: testcase '%v' summary (%+v) doesn't match what is expected (%+v)", tc.Name, *prSuite.E2eLogSummary, *tc.ExpectedSummary)
+			}
+		}
+	}
+}
+
+func TestLoadConformanceMetadata(t *testing.T) {
+	type testCase struct {
+		Name                string
+		Content             *string
+		ExpectedTestCount   int
+		ExpectedErrorString string
+	}
+	for _, tc := range []testCase{
+		{
+			Name: "valid metadata",
+			Content: common.Pointer(`- testname: works
+  codename: '[sig-cool] works'
+  description: it works
+  release: v1.30
+  file: test/e2e/cool.go
+- testname: also works
+  codename: '[sig-cool] also works'
+  description: it also works
+  release: v1.29, v1.30
+  file: test/e2e/cool.go
+`),
+			ExpectedTestCount: 2,
+		},
+		{
+			Name:                "not yaml",
+			Content:             common.Pointer(`hiiii`),
+			ExpectedErrorString: "the conformance metadata for release v1.30 is malformed",
+		},
+		{
+			Name:                "no tests",
+			Content:             common.Pointer(`[]`),
+			ExpectedErrorString: "the conformance metadata for release v1.30 contains no tests",
+		},
+		{
+			Name: "test without codename",
+			Content: common.Pointer(`- testname: works
+  release: v1.30
+`),
+			ExpectedErrorString: "test 1 names no codename",
+		},
+		{
+			Name: "test without release",
+			Content: common.Pointer(`- testname: works
+  codename: '[sig-cool] works'
+`),
+			ExpectedErrorString: "test '[sig-cool] works' names no release",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			metadataFolder := t.TempDir()
+			if err := os.MkdirAll(path.Join(metadataFolder, "v1.30"), 0755); err != nil {
+				t.Fatalf("error: creating metadata folder: %v", err)
+			}
+			if err := os.WriteFile(path.Join(metadataFolder, "v1.30", "conformance.yaml"), []byte(*tc.Content), 0644); err != nil {
+				t.Fatalf("error: writing conformance.yaml: %v", err)
+			}
+			metadata, err := LoadConformanceMetadata(metadataFolder, "v1.30")
+			if tc.ExpectedErrorString == "" {
+				if err != nil {
+					t.Fatalf("error: %v", err)
+				}
+				if len(metadata) != tc.ExpectedTestCount {
+					t.Fatalf("error: expected %v test(s), found %v", tc.ExpectedTestCount, len(metadata))
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedErrorString) {
+				t.Fatalf("error: expected an error containing '%v'; got %v", tc.ExpectedErrorString, err)
+			}
+		})
+	}
+
+	// a missing metadata file stays distinguishable from a corrupt one
+	if _, err := LoadConformanceMetadata(t.TempDir(), "v1.57"); !os.IsNotExist(err) {
+		t.Fatalf("error: expected a missing metadata file to be reported with os.IsNotExist; got %v", err)
+	}
+}
+
+func TestGetRequiredTests(t *testing.T) {
+	type testCase struct {
+		Name                string
+		Version             string
+		ExpectedTestsCount  int
+		ExpectedErrorString string
+		MetadataFolder      *string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:               "valid",
+			Version:            "v1.30",
+			ExpectedTestsCount: 402, // NOTE magic number is count of tests form conformance.yaml
+		},
+		{
+			Name:               "valid alternate version",
+			Version:            "v1.29",
+			ExpectedTestsCount: 388, // NOTE magic number is count of tests form conformance.yaml
+		},
+		{
+			Name:               "valid with test with version above pr version",
+			Version:            "v1.31",
+			MetadataFolder:     common.Pointer("testdata/metadata/version-of-test-higher"),
+			ExpectedTestsCount: 0,
+		},
+		{
+			Name:                "invalid with malformed version",
+			Version:             "v1.notfound",
+			ExpectedTestsCount:  0,
+			ExpectedErrorString: "Malformed version",
+		},
+		{
+			Name:                "invalid unable to parse conformance.yaml",
+			Version:             "v1.123",
+			ExpectedTestsCount:  0,
+			MetadataFolder:      common.Pointer("testdata/metadata/unable-to-parse"),
+			ExpectedErrorString: "cannot unmarshal string into Go value of type []suite.ConformanceTestMetadata",
+		},
+		{
+			Name:                "invalid version in conformance.yaml test",
+			Version:             "v1.27",
+			ExpectedTestsCount:  0,
+			MetadataFolder:      common.Pointer("testdata/metadata/bad-version-in-conformance.yaml"),
+			ExpectedErrorString: "Malformed version",
 		},
 	} {
 		t.Run(tc.Name, func(t *testing.T) {
@@ -1728,6 +2630,31 @@ func TestGetRequiredTests(t *testing.T) {
 	}
 }
 
+func Test_normalizeTestName(t *testing.T) {
+	type testCase struct {
+		Input          string
+		ExpectedOutput string
+	}
+	for _, tc := range []testCase{
+		{
+			Input:          "[It] [sig-node] a test that passes [Conformance]",
+			ExpectedOutput: "[sig-node] a test that passes [Conformance]",
+		},
+		{
+			Input:          "  [sig-node] a test that passes  [Conformance] ",
+			ExpectedOutput: "[sig-node] a test that passes [Conformance]",
+		},
+		{
+			Input:          "[ sig-node ] a test that passes [Conformance]",
+			ExpectedOutput: "[sig-node] a test that passes [Conformance]",
+		},
+	} {
+		if output := normalizeTestName(tc.Input); output != tc.ExpectedOutput {
+			t.Fatalf("error: normalized test name (%v) doesn't match what is expected (%v)", output, tc.ExpectedOutput)
+		}
+	}
+}
+
 func TestGetMissingJunitTestsFromPRSuite(t *testing.T) {
 	type testCase struct {
 		Name                      string
@@ -1767,6 +2694,34 @@ func TestGetMissingJunitTestsFromPRSuite(t *testing.T) {
 			},
 			ExpectedTestsMissingCount: 0,
 		},
+		{
+			Name:    "valid junit with whitespace-padded test names",
+			Version: "v1.30",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name:     "v1.30/coolkube/junit_01.xml",
+						BaseName: "junit_01.xml",
+						Contents: strings.ReplaceAll(testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01xml, `[Conformance]"`, `[Conformance] "`),
+					},
+				},
+			},
+			ExpectedTestsMissingCount: 0,
+		},
+		{
+			Name:    "junit with one required test missing",
+			Version: "v1.30",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name:     "v1.30/coolkube/junit_01.xml",
+						BaseName: "junit_01.xml",
+						Contents: testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01WithOneTestMissingxml,
+					},
+				},
+			},
+			ExpectedTestsMissingCount: 1,
+		},
 		{
 			Name:    "invalid with a metadata folder pointing to nowhere",
 			Version: "v1.30",
@@ -1886,6 +2841,96 @@ func TestGetJunitSubmittedConformanceTests(t *testing.T) {
 	}
 }
 
+func TestTheJunitFileIsWellFormedAndContainsTestResults(t *testing.T) {
+	type testCase struct {
+		Name                string
+		PullRequest         *PullRequest
+		ExpectedErrorString string
+		ExpectedCounts      *JunitTestCaseCounts
+	}
+	junitWithKnownCounts := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="Kubernetes e2e suite">
+    <testcase name="[It] [sig-node] a test that passes [Conformance]" classname="Kubernetes e2e suite" time="1"></testcase>
+    <testcase name="[It] [sig-node] another test that passes [Conformance]" classname="Kubernetes e2e suite" time="1"></testcase>
+    <testcase name="[It] [sig-cli] a test that fails [Conformance]" classname="Kubernetes e2e suite" time="1"><failure type="Failure">it broke</failure></testcase>
+    <testcase name="[It] [sig-storage] a test that is skipped" classname="Kubernetes e2e suite" time="0"><skipped></skipped></testcase>
+  </testsuite>
+</testsuites>`
+	for _, tc := range []testCase{
+		{
+			Name: "valid junit with known counts",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name:     "v1.30/coolkube/junit_01.xml",
+						BaseName: "junit_01.xml",
+						Contents: junitWithKnownCounts,
+					},
+				},
+			},
+			ExpectedCounts: &JunitTestCaseCounts{Total: 4, Passed: 2, Failed: 1, Skipped: 1},
+		},
+		{
+			Name: "malformed junit",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name:     "v1.30/coolkube/junit_01.xml",
+						BaseName: "junit_01.xml",
+						Contents: `<?xml version="1.0" encoding="UTF-8"?><testsuites><testsuite>`,
+					},
+				},
+			},
+			ExpectedErrorString: "unable to read test results from junit_01.xml",
+		},
+		{
+			Name: "junit without testcases",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name:     "v1.30/coolkube/junit_01.xml",
+						BaseName: "junit_01.xml",
+						Contents: `<?xml version="1.0" encoding="UTF-8"?><testsuites><testsuite name="Kubernetes e2e suite"></testsuite></testsuites>`,
+					},
+				},
+			},
+			ExpectedErrorString: "junit_01.xml contains no test results",
+		},
+	} {
+		prSuite := NewPRSuite(tc.PullRequest)
+		err := prSuite.theJunitFileIsWellFormedAndContainsTestResults()
+		if tc.ExpectedErrorString == "" && err != nil {
+			t.Fatalf("error in testcase '%v': %v", tc.Name, err)
+		}
+		if tc.ExpectedErrorString != "" {
+			if err == nil {
+				t.Fatalf("error: testcase '%v' expected an error containing '%v'", tc.Name, tc.ExpectedErrorString)
+			}
+			if !strings.Contains(err.Error(), tc.ExpectedErrorString) {
+				t.Fatalf("error: testcase '%v' error (%v) doesn't contain the expected string (%v)", tc.Name, err, tc.ExpectedErrorString)
+			}
+			foundLabel := false
+			for _, l := range prSuite.Labels {
+				if l == "evidence-missing" {
+					foundLabel = true
+				}
+			}
+			if !foundLabel {
+				t.Fatalf("error: testcase '%v' expected the label 'evidence-missing' in (%v)", tc.Name, prSuite.Labels)
+			}
+		}
+		if tc.ExpectedCounts != nil {
+			if prSuite.JunitTestCaseCounts == nil {
+				t.Fatalf("error: testcase '%v' expected testcase counts to be set", tc.Name)
+			}
+			if *prSuite.JunitTestCaseCounts != *tc.ExpectedCounts {
+				t.Fatalf("error: testcase '%v' counts (%+v) don't match what is expected (%+v)", tc.Name, *prSuite.JunitTestCaseCounts, *tc.ExpectedCounts)
+			}
+		}
+	}
+}
+
 func TestAllRequiredTestsInJunitXmlArePresent(t *testing.T) {
 	type testCase struct {
 		Name                string
@@ -1984,9 +3029,30 @@ func TestTheTestsPassAndAreSuccessful(t *testing.T) {
 					},
 				},
 			},
-			ExpectedLabels:      []string{"conformance-product-submission", "evidence-missing"},
+			ExpectedLabels:      []string{"conformance-product-submission", "failed-tests-present"},
 			ExpectedErrorString: "it appears that there are failures in some tests",
 		},
+		{
+			Name: "invalid with two tests failing",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{
+						Name:     "v1.30/coolkube/junit_01.xml",
+						BaseName: "junit_01.xml",
+						Contents: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="Kubernetes e2e suite">
+    <testcase name="[It] [sig-node] a test that fails [Conformance]" classname="Kubernetes e2e suite" time="1"><failure type="Failure">it broke</failure></testcase>
+    <testcase name="[It] [sig-cli] another test that fails [Conformance]" classname="Kubernetes e2e suite" time="1"><failure type="Failure">it also broke</failure></testcase>
+    <testcase name="[It] [sig-storage] a test that passes [Conformance]" classname="Kubernetes e2e suite" time="1"></testcase>
+  </testsuite>
+</testsuites>`,
+					},
+				},
+			},
+			ExpectedLabels:      []string{"conformance-product-submission", "failed-tests-present"},
+			ExpectedErrorString: "[sig-cli] another test that fails [Conformance]",
+		},
 		{
 			Name: "invalid with missing junit_01.xml",
 			PullRequest: &PullRequest{
@@ -2228,64 +3294,268 @@ func TestAPRTitle(t *testing.T) {
 	}
 }
 
-func TestGetLabelsAndCommentsFromSuiteResultsBuffer(t *testing.T) {
+func Test_artifactRunDatesDisagree(t *testing.T) {
 	type testCase struct {
-		Name                    string
-		PullRequest             *PullRequest
-		KubernetesVersion       *string
-		KubernetesVersionLatest *string
-		Buffer                  *bytes.Buffer
-		ExpectedComment         *string
-		ExpectedLabels          []string
-		ExpectedState           *string
-		ExpectedErrorString     string
+		Name              string
+		E2eLogContents    string
+		JunitContents     string
+		ExpectedE2eDate   string
+		ExpectedJunitDate string
+		ExpectedDisagree  bool
 	}
 
 	for _, tc := range []testCase{
 		{
-			Name: "invalid empty PR",
-			PullRequest: &PullRequest{
-				PullRequestQuery:        PullRequestQuery{},
-				Labels:                  []string{},
-				SupportingFiles:         []*PullRequestFile{},
-				ProductYAMLURLDataTypes: map[string]string{},
-			},
-			ExpectedErrorString: "Malformed version",
+			Name:           "correlated artifacts",
+			E2eLogContents: "I0305 10:00:00.000 starting run 2024-03-05\nRan 2 of 2 Specs",
+			JunitContents:  `<testsuite name="e2e" timestamp="2024-03-05T10:00:00" tests="2"></testsuite>`,
 		},
 		{
-			Name:              "invalid with KubernetesVersion",
-			KubernetesVersion: common.Pointer("v1.30"),
-			PullRequest: &PullRequest{
-				PullRequestQuery:        PullRequestQuery{},
-				Labels:                  []string{},
-				SupportingFiles:         []*PullRequestFile{},
-				ProductYAMLURLDataTypes: map[string]string{},
-			},
+			Name:              "artifacts from different runs",
+			E2eLogContents:    "starting run 2024-03-01\nRan 2 of 2 Specs",
+			JunitContents:     `<testsuite name="e2e" timestamp="2024-03-05T10:00:00" tests="2"></testsuite>`,
+			ExpectedE2eDate:   "2024-03-01",
+			ExpectedJunitDate: "2024-03-05",
+			ExpectedDisagree:  true,
 		},
 		{
-			Name:                    "invalid with KubernetesVersion and KubernetesVersionLatest",
-			KubernetesVersion:       common.Pointer("v1.30"),
-			KubernetesVersionLatest: common.Pointer("v1.30"),
-			PullRequest: &PullRequest{
-				PullRequestQuery:        PullRequestQuery{},
-				Labels:                  []string{},
-				SupportingFiles:         []*PullRequestFile{},
-				ProductYAMLURLDataTypes: map[string]string{},
-			},
-			ExpectedLabels: []string{"conformance-product-submission", "missing-file-README.md", "missing-file-PRODUCT.yaml", "missing-file-e2e.log", "missing-file-junit_01.xml", "release-v1.30", "not-verifiable"},
+			Name:           "e2e.log carries no dates",
+			E2eLogContents: "Ran 2 of 2 Specs in 10.0 seconds",
+			JunitContents:  `<testsuite name="e2e" timestamp="2024-03-05T10:00:00" tests="2"></testsuite>`,
 		},
 		{
-			Name:                    "invalid with non-cuke contents",
-			KubernetesVersion:       common.Pointer("v1.30"),
-			KubernetesVersionLatest: common.Pointer("v1.30"),
-			Buffer:                  bytes.NewBuffer([]byte(`hiiii`)),
-			PullRequest: &PullRequest{
-				PullRequestQuery:        PullRequestQuery{},
-				Labels:                  []string{},
-				SupportingFiles:         []*PullRequestFile{},
-				ProductYAMLURLDataTypes: map[string]string{},
+			Name:           "junit carries no timestamp",
+			E2eLogContents: "starting run 2024-03-01\nRan 2 of 2 Specs",
+			JunitContents:  `<testsuite name="e2e" tests="2"></testsuite>`,
+		},
+	} {
+		prSuite := NewPRSuite(&PullRequest{
+			SupportingFiles: []*PullRequestFile{
+				{BaseName: "e2e.log", Contents: tc.E2eLogContents},
+				{BaseName: "junit_01.xml", Contents: tc.JunitContents},
 			},
-			ExpectedErrorString: "invalid character 'h' looking for beginning of value",
+		})
+		e2eDate, junitDate, disagree := prSuite.artifactRunDatesDisagree()
+		if disagree != tc.ExpectedDisagree {
+			t.Fatalf("error: disagree in test case '%v' result '%v' does not match expected '%v'", tc.Name, disagree, tc.ExpectedDisagree)
+		}
+		if e2eDate != tc.ExpectedE2eDate || junitDate != tc.ExpectedJunitDate {
+			t.Fatalf("error: dates in test case '%v' result '%v'/'%v' do not match expected '%v'/'%v'", tc.Name, e2eDate, junitDate, tc.ExpectedE2eDate, tc.ExpectedJunitDate)
+		}
+	}
+
+	// a submission missing either artifact is inconclusive rather than flagged
+	if _, _, disagree := NewPRSuite(&PullRequest{}).artifactRunDatesDisagree(); disagree {
+		t.Fatalf("error: expected no disagreement for a PR without artifacts")
+	}
+}
+
+func Test_artifactStats(t *testing.T) {
+	prSuite := NewPRSuite(&PullRequest{
+		SupportingFiles: []*PullRequestFile{
+			{BaseName: "README.md", Contents: strings.Repeat("a", 16)},
+			{BaseName: "e2e.log", Contents: strings.Repeat("b", 2500)},
+			{BaseName: "junit_01.xml", Contents: strings.Repeat("c", 3*1000*1000)},
+			// an oversized file whose contents were dropped after fetching;
+			// the size as fetched is the one reported
+			{BaseName: "extra.tar", Contents: "", FetchedSize: 5 * 1000 * 1000},
+		},
+	})
+	prSuite.JunitTestCaseCounts = &JunitTestCaseCounts{Total: 7201}
+	expected := "Submitted artifact sizes:\n- README.md: 16 B\n- e2e.log: 2.5 kB\n- junit_01.xml: 3.0 MB (7201 testcase(s))\n- extra.tar: 5.0 MB"
+	if stats := prSuite.artifactStats(); stats != expected {
+		t.Fatalf("error: stats '%v' does not match expected '%v'", stats, expected)
+	}
+	if stats := NewPRSuite(&PullRequest{}).artifactStats(); stats != "" {
+		t.Fatalf("error: expected no stats for a PR without files; got '%v'", stats)
+	}
+}
+
+func Test_checksumMismatches(t *testing.T) {
+	digestFor := func(content string) string {
+		digest := sha256.Sum256([]byte(content))
+		return hex.EncodeToString(digest[:])
+	}
+	e2eContents := "will run for conformance"
+	junitContents := `<testsuite></testsuite>`
+	type testCase struct {
+		Name               string
+		SupportingFiles    []*PullRequestFile
+		ExpectedMismatches []string
+	}
+	for _, tc := range []testCase{
+		{
+			Name: "no checksum file skips the check",
+			SupportingFiles: []*PullRequestFile{
+				{BaseName: "e2e.log", Contents: e2eContents},
+			},
+			ExpectedMismatches: []string{},
+		},
+		{
+			Name: "matching checksums",
+			SupportingFiles: []*PullRequestFile{
+				{BaseName: "e2e.log", Contents: e2eContents},
+				{BaseName: "junit_01.xml", Contents: junitContents},
+				{
+					BaseName: "SHA256SUMS",
+					Contents: digestFor(e2eContents) + "  e2e.log\n" +
+						digestFor(junitContents) + "  *junit_01.xml\n",
+				},
+			},
+			ExpectedMismatches: []string{},
+		},
+		{
+			Name: "mismatching checksum flags the file",
+			SupportingFiles: []*PullRequestFile{
+				{BaseName: "e2e.log", Contents: e2eContents + " with extra lines"},
+				{BaseName: "junit_01.xml", Contents: junitContents},
+				{
+					BaseName: "SHA256SUMS",
+					Contents: digestFor(e2eContents) + "  e2e.log\n" +
+						digestFor(junitContents) + "  junit_01.xml\n",
+				},
+			},
+			ExpectedMismatches: []string{"e2e.log"},
+		},
+		{
+			Name: "entries for absent files are ignored",
+			SupportingFiles: []*PullRequestFile{
+				{BaseName: "e2e.log", Contents: e2eContents},
+				{
+					BaseName: "SHA256SUMS",
+					Contents: digestFor(e2eContents) + "  e2e.log\n" +
+						digestFor(junitContents) + "  junit_01.xml\n" +
+						"not a checksum line\n",
+				},
+			},
+			ExpectedMismatches: []string{},
+		},
+		{
+			Name: "truncated oversized files are skipped",
+			SupportingFiles: []*PullRequestFile{
+				{BaseName: "e2e.log", Contents: e2eContents, FetchedSize: len(e2eContents) * 10},
+				{
+					BaseName: "SHA256SUMS",
+					Contents: digestFor(e2eContents) + "  e2e.log\n",
+				},
+			},
+			ExpectedMismatches: []string{},
+		},
+		{
+			Name: "sanitized e2e.log is verified against the uploaded bytes",
+			SupportingFiles: []*PullRequestFile{
+				{BaseName: "e2e.log", Contents: e2eContents, RawContents: "\x1b[32m" + e2eContents},
+				{
+					BaseName: "SHA256SUMS",
+					Contents: digestFor("\x1b[32m"+e2eContents) + "  e2e.log\n",
+				},
+			},
+			ExpectedMismatches: []string{},
+		},
+	} {
+		prSuite := NewPRSuite(&PullRequest{SupportingFiles: tc.SupportingFiles})
+		mismatches := prSuite.checksumMismatches()
+		if len(mismatches) != len(tc.ExpectedMismatches) {
+			t.Fatalf("error: mismatches (%v) in testcase '%v' don't match what is expected (%v)", mismatches, tc.Name, tc.ExpectedMismatches)
+		}
+		for i := range mismatches {
+			if mismatches[i] != tc.ExpectedMismatches[i] {
+				t.Fatalf("error: mismatches (%v) in testcase '%v' don't match what is expected (%v)", mismatches, tc.Name, tc.ExpectedMismatches)
+			}
+		}
+	}
+}
+
+func TestGetLabelsAndCommentsFromSuiteResultsBuffer(t *testing.T) {
+	type testCase struct {
+		Name                    string
+		PullRequest             *PullRequest
+		KubernetesVersion       *string
+		KubernetesVersionLatest *string
+		Buffer                  *bytes.Buffer
+		ExpectedComment         *string
+		ExpectedLabels          []string
+		ExpectedState           *string
+		ExpectedErrorString     string
+	}
+
+	validSupportingFiles := []*PullRequestFile{
+		{
+			Name:     "v1.30/coolkube/PRODUCT.yaml",
+			BaseName: "PRODUCT.yaml",
+			Contents: `vendor: "cool"
+name: "coolkube"
+version: "v1.30"
+type: "distribution"
+description: "it's just all-round cool and probably the best k8s, idk"
+website_url: "https://coolkubernetes.com"
+documentation_url: "https://coolkubernetes.com/docs"
+contact_email_address: "sales@coolkubernetes.com"`,
+		},
+		{
+			Name:     "v1.30/coolkube/README.md",
+			BaseName: "README.md",
+			Contents: `# v1.30/coolkube`,
+		},
+		{
+			Name:     "v1.30/coolkube/e2e.log",
+			BaseName: "e2e.log",
+			Contents: `stuff here
+Ran 406 of 7201 Specs in 6096.544 seconds
+SUCCESS! -- 406 Passed | 0 Failed | 0 Pending | 6795 Skipped`,
+		},
+		{
+			Name:     "v1.30/coolkube/junit_01.xml",
+			BaseName: "junit_01.xml",
+			Contents: testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01xml,
+		},
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name: "invalid empty PR",
+			PullRequest: &PullRequest{
+				PullRequestQuery:        PullRequestQuery{},
+				Labels:                  []string{},
+				SupportingFiles:         []*PullRequestFile{},
+				ProductYAMLURLDataTypes: map[string]string{},
+			},
+			ExpectedErrorString: "Malformed version",
+		},
+		{
+			Name:              "invalid with KubernetesVersion",
+			KubernetesVersion: common.Pointer("v1.30"),
+			PullRequest: &PullRequest{
+				PullRequestQuery:        PullRequestQuery{},
+				Labels:                  []string{},
+				SupportingFiles:         []*PullRequestFile{},
+				ProductYAMLURLDataTypes: map[string]string{},
+			},
+		},
+		{
+			Name:                    "invalid with KubernetesVersion and KubernetesVersionLatest",
+			KubernetesVersion:       common.Pointer("v1.30"),
+			KubernetesVersionLatest: common.Pointer("v1.30"),
+			PullRequest: &PullRequest{
+				PullRequestQuery:        PullRequestQuery{},
+				Labels:                  []string{},
+				SupportingFiles:         []*PullRequestFile{},
+				ProductYAMLURLDataTypes: map[string]string{},
+			},
+			ExpectedLabels: []string{"conformance-product-submission", "missing-file-README.md", "missing-file-PRODUCT.yaml", "missing-file-e2e.log", "missing-file-junit_01.xml", "release-v1.30", "not-verifiable"},
+		},
+		{
+			Name:                    "invalid with non-cuke contents",
+			KubernetesVersion:       common.Pointer("v1.30"),
+			KubernetesVersionLatest: common.Pointer("v1.30"),
+			Buffer:                  bytes.NewBuffer([]byte(`hiiii`)),
+			PullRequest: &PullRequest{
+				PullRequestQuery:        PullRequestQuery{},
+				Labels:                  []string{},
+				SupportingFiles:         []*PullRequestFile{},
+				ProductYAMLURLDataTypes: map[string]string{},
+			},
+			ExpectedErrorString: "invalid character 'h' looking for beginning of value",
 		},
 		{
 			Name:                    "invalid with missing supporting conformance.yaml for KubernetesVersion",
@@ -2297,9 +3567,9 @@ func TestGetLabelsAndCommentsFromSuiteResultsBuffer(t *testing.T) {
 				SupportingFiles:         []*PullRequestFile{},
 				ProductYAMLURLDataTypes: map[string]string{},
 			},
-			ExpectedLabels:  []string{"conformance-product-submission", "unable-to-process"},
+			ExpectedLabels:  []string{"conformance-product-submission", "release-not-yet-available"},
 			ExpectedState:   common.Pointer("pending"),
-			ExpectedComment: common.Pointer("The release version v1.123 is unable to be processed at this time; Please wait as this version may become available soon."),
+			ExpectedComment: common.Pointer("The release version v1.123 is valid, but its conformance metadata has not yet been published; the checks will run once it becomes available."),
 		},
 		{
 			Name:                    "valid pull request",
@@ -2364,39 +3634,16 @@ func TestGetLabelsAndCommentsFromSuiteResultsBuffer(t *testing.T) {
 						},
 					},
 				},
-				SupportingFiles: []*PullRequestFile{
-					{
-						Name:     "v1.30/coolkube/PRODUCT.yaml",
-						BaseName: "PRODUCT.yaml",
-						Contents: `vendor: "cool"
-name: "coolkube"
-version: "v1.30"
-type: "distribution"
-description: "it's just all-round cool and probably the best k8s, idk"
-website_url: "https://coolkubernetes.com"
-documentation_url: "https://coolkubernetes.com/docs"
-contact_email_address: "sales@coolkubernetes.com"`,
-					},
-					{
-						Name:     "v1.30/coolkube/README.md",
-						BaseName: "README.md",
-						Contents: `# v1.30/coolkube`,
-					},
-					{
-						Name:     "v1.30/coolkube/e2e.log",
-						BaseName: "e2e.log",
-						Contents: `stuff here`,
-					},
-					{
-						Name:     "v1.30/coolkube/junit_01.xml",
-						BaseName: "junit_01.xml",
-						Contents: testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01xml,
-					},
-				},
+				SupportingFiles:         validSupportingFiles,
 				ProductYAMLURLDataTypes: map[string]string{},
 			},
-			ExpectedLabels:  []string{"conformance-product-submission", "tests-verified-v1.30", "no-failed-tests-v1.30", "release-v1.30", "release-documents-checked"},
-			ExpectedComment: common.Pointer("All requirements (15) have passed for the submission!\n"),
+			ExpectedLabels: []string{"conformance-product-submission", "tests-verified-v1.30", "no-failed-tests-v1.30", "release-v1.30", "release-documents-checked"},
+			ExpectedComment: common.Pointer(fmt.Sprintf(
+				"All requirements (21) have passed for the submission!\n\njunit_01.xml reports 7201 test(s): 406 passed, 0 failed, 6795 skipped.\n\ne2e.log reports 406 of 7201 spec(s) ran: 406 passed, 0 failed.\n\nSubmitted artifact sizes:\n- PRODUCT.yaml: %v\n- README.md: %v\n- e2e.log: %v\n- junit_01.xml: %v (7201 testcase(s))\n",
+				humanReadableArtifactSize(len(validSupportingFiles[0].Contents)),
+				humanReadableArtifactSize(len(validSupportingFiles[1].Contents)),
+				humanReadableArtifactSize(len(validSupportingFiles[2].Contents)),
+				humanReadableArtifactSize(len(validSupportingFiles[3].Contents)))),
 		},
 	} {
 		prSuite := NewPRSuite(tc.PullRequest)
@@ -2427,6 +3674,854 @@ contact_email_address: "sales@coolkubernetes.com"`,
 	}
 }
 
+func TestTheVersionsInTheTitleDirectoryAndProductYAMLAgree(t *testing.T) {
+	type testCase struct {
+		Name                     string
+		Title                    string
+		KubernetesReleaseVersion string
+		ProductYAMLVersion       string
+		ExpectedErrorString      string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name:                     "all sources agree despite prefix and patch differences",
+			Title:                    "Conformance results for v1.30/coolkube",
+			KubernetesReleaseVersion: "v1.30",
+			ProductYAMLVersion:       "1.30.2",
+		},
+		{
+			Name:                     "title and directory disagree",
+			Title:                    "Conformance results for v1.30/coolkube",
+			KubernetesReleaseVersion: "v1.29",
+			ProductYAMLVersion:       "v1.30",
+			ExpectedErrorString:      "the submission directory names v1.29",
+		},
+		{
+			Name:                     "directory and PRODUCT.yaml disagree",
+			Title:                    "Conformance results for v1.29/coolkube",
+			KubernetesReleaseVersion: "v1.29",
+			ProductYAMLVersion:       "v1.30.1",
+			ExpectedErrorString:      "the version field in PRODUCT.yaml names v1.30.1",
+		},
+		{
+			Name:                "title and PRODUCT.yaml disagree",
+			Title:               "Conformance results for v1.30/coolkube",
+			ProductYAMLVersion:  "1.29",
+			ExpectedErrorString: "the version field in PRODUCT.yaml names 1.29",
+		},
+	} {
+		prSuite := NewPRSuite(&PullRequest{
+			PullRequestQuery: PullRequestQuery{
+				Title: githubql.String(tc.Title),
+			},
+			SupportingFiles: []*PullRequestFile{
+				{
+					Name:     "v1.30/coolkube/PRODUCT.yaml",
+					BaseName: "PRODUCT.yaml",
+					Contents: fmt.Sprintf(`vendor: "cool"
+name: "coolkube"
+version: "%v"`, tc.ProductYAMLVersion),
+				},
+			},
+		})
+		prSuite.KubernetesReleaseVersion = tc.KubernetesReleaseVersion
+		err := prSuite.theVersionsInTheTitleDirectoryAndProductYAMLAgree()
+		if tc.ExpectedErrorString == "" {
+			if err != nil {
+				t.Fatalf("error: testcase (%v) unexpected error: %v", tc.Name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.ExpectedErrorString) {
+			t.Fatalf("error: testcase (%v) error (%v) doesn't contain expected string (%v)", tc.Name, err, tc.ExpectedErrorString)
+		}
+		foundLabel := false
+		for _, label := range prSuite.Labels {
+			if label == "version-mismatch" {
+				foundLabel = true
+			}
+		}
+		if !foundLabel {
+			t.Fatalf("error: testcase (%v) expected the version-mismatch label to be set; got %v", tc.Name, prSuite.Labels)
+		}
+	}
+}
+
+func TestGetProductYAMLUnexpectedKeys(t *testing.T) {
+	type testCase struct {
+		Name         string
+		PullRequest  *PullRequest
+		ExpectedKeys []string
+	}
+
+	for _, tc := range []testCase{
+		{
+			Name: "clean file has no unexpected keys",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{
+						BaseName: "PRODUCT.yaml",
+						Contents: `vendor: "cool"
+name: "coolkube"
+version: "v1.30"
+type: "distribution"
+description: "it's just all-round cool and probably the best k8s, idk"
+website_url: "https://coolkubernetes.com"
+repo_url: "https://github.com/coolkube/coolkube"
+documentation_url: "https://coolkubernetes.com/docs"
+product_logo_url: "https://coolkubernetes.com/logo.svg"
+contact_email_address: "sales@coolkubernetes.com"`,
+					},
+				},
+			},
+			ExpectedKeys: []string{},
+		},
+		{
+			Name: "misspelled and extraneous keys are reported",
+			PullRequest: &PullRequest{
+				SupportingFiles: []*PullRequestFile{
+					{
+						BaseName: "PRODUCT.yaml",
+						Contents: `vendor: "cool"
+name: "coolkube"
+web_url: "https://coolkubernetes.com"
+something_else: "what is this"`,
+					},
+				},
+			},
+			ExpectedKeys: []string{"something_else", "web_url"},
+		},
+		{
+			Name:         "missing file has no unexpected keys",
+			PullRequest:  &PullRequest{},
+			ExpectedKeys: []string{},
+		},
+	} {
+		prSuite := NewPRSuite(tc.PullRequest)
+		if keys := prSuite.GetProductYAMLUnexpectedKeys(); !reflect.DeepEqual(keys, tc.ExpectedKeys) {
+			t.Fatalf("error: testcase (%v) unexpected keys (%v) don't match expected keys (%v)", tc.Name, keys, tc.ExpectedKeys)
+		}
+	}
+}
+
+func TestGetJunitTestCasesAcrossMultipleFiles(t *testing.T) {
+	prSuite := NewPRSuite(&PullRequest{
+		SupportingFiles: []*PullRequestFile{
+			{
+				Name:     "v1.30/coolkube/junit_01.xml",
+				BaseName: "junit_01.xml",
+				Contents: `<testsuites>
+  <testsuite>
+    <testcase name="[It] [sig-node] test a [Conformance]"></testcase>
+    <testcase name="[It] [sig-cli] test b [Conformance]"><skipped></skipped></testcase>
+  </testsuite>
+</testsuites>`,
+			},
+			{
+				Name:     "v1.30/coolkube/junit_02.xml",
+				BaseName: "junit_02.xml",
+				Contents: `<testsuites>
+  <testsuite>
+    <testcase name="[It] [sig-cli] test b [Conformance]"></testcase>
+    <testcase name="[It] [sig-network] test c [Conformance]"><failure>it broke</failure></testcase>
+  </testsuite>
+</testsuites>`,
+			},
+		},
+	})
+	counts, err := prSuite.GetJunitTestCaseCounts()
+	if err != nil {
+		t.Fatalf("error: counting testcases across junit files: %v", err)
+	}
+	if counts.Total != 3 || counts.Passed != 2 || counts.Failed != 1 || counts.Skipped != 0 {
+		t.Fatalf("error: counts (%+v) don't match expected merged counts of 3 total, 2 passed, 1 failed, 0 skipped", counts)
+	}
+	failedTests, err := prSuite.GetJunitFailedTests()
+	if err != nil {
+		t.Fatalf("error: collecting failed tests across junit files: %v", err)
+	}
+	if !reflect.DeepEqual(failedTests, []string{"[sig-network] test c [Conformance]"}) {
+		t.Fatalf("error: failed tests (%v) don't match what is expected", failedTests)
+	}
+	submittedTests, err := prSuite.GetJunitSubmittedConformanceTests()
+	if err != nil {
+		t.Fatalf("error: collecting submitted tests across junit files: %v", err)
+	}
+	if len(submittedTests) != 2 {
+		t.Fatalf("error: expected the duplicated testcase to be merged; got %v submitted test(s)", len(submittedTests))
+	}
+}
+
+func TestTheFilesIncludedInThePRAreOnlyWithExtraJunitFiles(t *testing.T) {
+	prSuite := NewPRSuite(&PullRequest{
+		SupportingFiles: []*PullRequestFile{
+			{BaseName: "README.md"},
+			{BaseName: "PRODUCT.yaml"},
+			{BaseName: "e2e.log"},
+			{BaseName: "junit_01.xml"},
+			{BaseName: "junit_02.xml"},
+		},
+	})
+	if err := prSuite.theFilesIncludedInThePRAreOnly("README.md, PRODUCT.yaml, e2e.log, junit_01.xml"); err != nil {
+		t.Fatalf("error: additional numbered junit files must be accepted: %v", err)
+	}
+}
+
+func TestGetResultsJSON(t *testing.T) {
+	prSuite := NewPRSuite(&PullRequest{})
+	prSuite.KubernetesReleaseVersion = "v1.30"
+	prSuite.MissingFiles = []string{"junit_01.xml"}
+	prSuite.Labels = append(prSuite.Labels, "not-verifiable")
+	prSuite.buffer = *bytes.NewBufferString(`[
+  {
+    "name": "verify conformance product submission PR",
+    "elements": [
+      {
+        "name": "all tests pass",
+        "description": "it appears that some tests failed in the product submission",
+        "steps": [
+          {
+            "name": "the tests pass and are successful",
+            "result": {
+              "status": "failed",
+              "error_message": "it appears that there are failures in some tests"
+            }
+          }
+        ]
+      },
+      {
+        "name": "there is only one commit",
+        "steps": [
+          {
+            "name": "there is only one commit",
+            "result": {
+              "status": "passed"
+            }
+          }
+        ]
+      }
+    ]
+  }
+]`)
+	output, err := prSuite.GetResultsJSON()
+	if err != nil {
+		t.Fatalf("error: serializing results to JSON: %v", err)
+	}
+	results := ResultsJSON{}
+	if err := json.Unmarshal([]byte(output), &results); err != nil {
+		t.Fatalf("error: the results JSON must round-trip: %v", err)
+	}
+	if results.Version != "v1.30" {
+		t.Fatalf("error: version (%v) doesn't match expected version (v1.30)", results.Version)
+	}
+	if !reflect.DeepEqual(results.MissingFiles, []string{"junit_01.xml"}) {
+		t.Fatalf("error: missing files (%v) don't match what is expected", results.MissingFiles)
+	}
+	if !reflect.DeepEqual(results.Labels, []string{"conformance-product-submission", "not-verifiable"}) {
+		t.Fatalf("error: labels (%v) don't match what is expected", results.Labels)
+	}
+	if len(results.Requirements) != 2 {
+		t.Fatalf("error: expected 2 requirements; got %v", len(results.Requirements))
+	}
+	if results.Requirements[0].Name != "all tests pass" || results.Requirements[0].Passed {
+		t.Fatalf("error: requirement (%+v) should be a failure", results.Requirements[0])
+	}
+	if !reflect.DeepEqual(results.Requirements[0].Hints, []string{"it appears that there are failures in some tests"}) {
+		t.Fatalf("error: hints (%v) don't match what is expected", results.Requirements[0].Hints)
+	}
+	if results.Requirements[1].Name != "there is only one commit" || !results.Requirements[1].Passed {
+		t.Fatalf("error: requirement (%+v) should be a pass", results.Requirements[1])
+	}
+}
+
+func TestGetRequirementResultsFromSuiteResultsBuffer(t *testing.T) {
+	prSuite := NewPRSuite(&PullRequest{})
+	prSuite.KubernetesReleaseVersion = "v1.30"
+	prSuite.KubernetesReleaseVersionLatest = "v1.30"
+	prSuite.buffer = *bytes.NewBufferString(`[
+  {
+    "name": "verify conformance product submission PR",
+    "elements": [
+      {
+        "name": "all tests pass",
+        "description": "it appears that some tests failed in the product submission",
+        "steps": [
+          {
+            "name": "the tests pass and are successful",
+            "result": {
+              "status": "failed",
+              "error_message": "it appears that there are failures in some tests"
+            }
+          }
+        ]
+      },
+      {
+        "name": "there is only one commit",
+        "steps": [
+          {
+            "name": "there is only one commit",
+            "result": {
+              "status": "passed"
+            }
+          }
+        ]
+      }
+    ]
+  }
+]`)
+	requirements, err := prSuite.GetRequirementResultsFromSuiteResultsBuffer()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if len(requirements) != 2 {
+		t.Fatalf("error: expected 2 requirements; got %v", len(requirements))
+	}
+	if requirements[0].Name != "all tests pass" ||
+		requirements[0].Description != "it appears that some tests failed in the product submission" ||
+		requirements[0].Passed {
+		t.Fatalf("error: requirement (%+v) should be a failure", requirements[0])
+	}
+	if requirements[1].Name != "there is only one commit" || !requirements[1].Passed {
+		t.Fatalf("error: requirement (%+v) should be a pass", requirements[1])
+	}
+	comment, _, state, err := prSuite.GetLabelsAndCommentsFromSuiteResultsBuffer()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if state != "failure" {
+		t.Fatalf("error: state (%v) doesn't match expected state (failure)", state)
+	}
+	if !strings.Contains(comment, "1 of 2 requirements have passed") {
+		t.Fatalf("error: the comment must reflect the structured counts; got '%v'", comment)
+	}
+	for _, requirement := range requirements {
+		if requirement.Passed {
+			continue
+		}
+		if !strings.Contains(comment, "- [FAIL] "+requirement.Description) {
+			t.Fatalf("error: the comment must name the failed requirement '%v'; got '%v'", requirement.Description, comment)
+		}
+		for _, hint := range requirement.Hints {
+			if !strings.Contains(comment, hint) {
+				t.Fatalf("error: the comment must include the hint '%v'; got '%v'", hint, comment)
+			}
+		}
+	}
+}
+
+func TestExtraRequiredFiles(t *testing.T) {
+	defaultExtraRequiredFiles := extraRequiredFiles
+	defer func() {
+		extraRequiredFiles = defaultExtraRequiredFiles
+	}()
+	if err := SetExtraRequiredFiles([]string{"signed-eula.pdf", "*.sig"}); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	passingBuffer := `[
+  {
+    "name": "verify conformance product submission PR",
+    "elements": [
+      {
+        "name": "there is only one commit",
+        "steps": [
+          {
+            "name": "there is only one commit",
+            "result": {
+              "status": "passed"
+            }
+          }
+        ]
+      }
+    ]
+  }
+]`
+
+	// with the extra files present, literally and by glob, nothing is flagged
+	prSuite := NewPRSuite(&PullRequest{
+		SupportingFiles: []*PullRequestFile{
+			{BaseName: "signed-eula.pdf"},
+			{BaseName: "e2e.log.sig"},
+		},
+	})
+	prSuite.KubernetesReleaseVersion = "v1.30"
+	prSuite.KubernetesReleaseVersionLatest = "v1.30"
+	prSuite.buffer = *bytes.NewBufferString(passingBuffer)
+	comment, labels, state, err := prSuite.GetLabelsAndCommentsFromSuiteResultsBuffer()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if state != "success" {
+		t.Fatalf("error: state (%v) doesn't match expected state (success)", state)
+	}
+	for _, l := range labels {
+		if strings.HasPrefix(l, "missing-file-") {
+			t.Fatalf("error: no missing file label must be added when the extra files are present; got '%v'", l)
+		}
+	}
+	if strings.Contains(comment, "is missing from the submission") {
+		t.Fatalf("error: unexpected comment '%v'", comment)
+	}
+
+	// with the extra files absent, each pattern is labelled and commented on
+	prSuite = NewPRSuite(&PullRequest{
+		SupportingFiles: []*PullRequestFile{
+			{BaseName: "e2e.log"},
+		},
+	})
+	prSuite.KubernetesReleaseVersion = "v1.30"
+	prSuite.KubernetesReleaseVersionLatest = "v1.30"
+	prSuite.buffer = *bytes.NewBufferString(passingBuffer)
+	comment, labels, state, err = prSuite.GetLabelsAndCommentsFromSuiteResultsBuffer()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if state != "failure" {
+		t.Fatalf("error: state (%v) doesn't match expected state (failure)", state)
+	}
+	for _, missingLabel := range []string{"missing-file-signed-eula.pdf", "missing-file-*.sig"} {
+		found := false
+		for _, l := range labels {
+			if l == missingLabel {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("error: expected the label '%v'; got %v", missingLabel, labels)
+		}
+	}
+	if !reflect.DeepEqual(prSuite.MissingFiles, []string{"signed-eula.pdf", "*.sig"}) {
+		t.Fatalf("error: missing files (%v) don't match what is expected", prSuite.MissingFiles)
+	}
+	if !strings.Contains(comment, "The required file 'signed-eula.pdf' is missing from the submission") {
+		t.Fatalf("error: the comment must name the missing file; got '%v'", comment)
+	}
+
+	// an invalid glob pattern is rejected
+	if err := SetExtraRequiredFiles([]string{"["}); err == nil {
+		t.Fatalf("error: expected an invalid glob pattern to be rejected")
+	}
+}
+
+func TestExtraRequiredFilesEndToEnd(t *testing.T) {
+	defaultExtraRequiredFiles := extraRequiredFiles
+	defer func() {
+		extraRequiredFiles = defaultExtraRequiredFiles
+	}()
+	if err := SetExtraRequiredFiles([]string{"signed-eula.pdf"}); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	prSuite := NewPRSuite(&PullRequest{
+		PullRequestQuery: PullRequestQuery{
+			Title: githubql.String("Conformance results for v1.30/coolkube"),
+			Commits: struct {
+				Nodes []struct {
+					Commit struct {
+						Oid    githubql.String
+						Status struct {
+							Contexts []struct {
+								Context githubql.String
+								State   githubql.String
+							}
+						}
+					}
+				}
+			}{
+				Nodes: []struct {
+					Commit struct {
+						Oid    githubql.String
+						Status struct {
+							Contexts []struct {
+								Context githubql.String
+								State   githubql.String
+							}
+						}
+					}
+				}{
+					{},
+				},
+			},
+		},
+		SupportingFiles: []*PullRequestFile{
+			{
+				Name:     "v1.30/coolkube/PRODUCT.yaml",
+				BaseName: "PRODUCT.yaml",
+				Contents: `vendor: "cool"
+name: "coolkube"
+version: "v1.30"
+type: "distribution"
+description: "it's just all-round cool and probably the best k8s, idk"
+website_url: "https://coolkubernetes.com"
+documentation_url: "https://coolkubernetes.com/docs"
+contact_email_address: "sales@coolkubernetes.com"`,
+			},
+			{
+				Name:     "v1.30/coolkube/README.md",
+				BaseName: "README.md",
+				Contents: `# v1.30/coolkube`,
+			},
+			{
+				Name:     "v1.30/coolkube/e2e.log",
+				BaseName: "e2e.log",
+				Contents: `stuff here
+Ran 406 of 7201 Specs in 6096.544 seconds
+SUCCESS! -- 406 Passed | 0 Failed | 0 Pending | 6795 Skipped`,
+			},
+			{
+				Name:     "v1.30/coolkube/junit_01.xml",
+				BaseName: "junit_01.xml",
+				Contents: testGetJunitSubmittedConformanceTestsCoolkubeV130Junit_01xml,
+			},
+			{
+				Name:     "v1.30/coolkube/signed-eula.pdf",
+				BaseName: "signed-eula.pdf",
+				Contents: "signed",
+			},
+		},
+		ProductYAMLURLDataTypes: map[string]string{},
+	})
+	prSuite.KubernetesReleaseVersion = "v1.30"
+	prSuite.KubernetesReleaseVersionLatest = "v1.30"
+	prSuite.SetSubmissionMetadatafromFolderStructure()
+	prSuite.NewTestSuite(PRSuiteOptions{Paths: []string{"../../kodata/features/verify-conformance.feature"}}).Run()
+	comment, labels, state, err := prSuite.GetLabelsAndCommentsFromSuiteResultsBuffer()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if state != "success" {
+		t.Fatalf("error: a submission including a configured extra file must pass; got state '%v' with comment '%v'", state, comment)
+	}
+	if strings.Contains(comment, "non-required file") {
+		t.Fatalf("error: the configured extra file must not be flagged as non-required; got '%v'", comment)
+	}
+	for _, l := range labels {
+		if strings.HasPrefix(l, "missing-file-") || l == "not-verifiable" {
+			t.Fatalf("error: unexpected label '%v' with comment '%v'", l, comment)
+		}
+	}
+}
+
+func Test_failingScenarioTitlesInComment(t *testing.T) {
+	originalMaxFailures := maxFailuresInComment
+	maxFailuresInComment = 2
+	defer func() {
+		maxFailuresInComment = originalMaxFailures
+	}()
+	prSuite := NewPRSuite(&PullRequest{})
+	prSuite.KubernetesReleaseVersion = "v1.30"
+	prSuite.KubernetesReleaseVersionLatest = "v1.30"
+	prSuite.buffer = *bytes.NewBufferString(`[
+  {
+    "name": "verify conformance product submission PR",
+    "elements": [
+      {
+        "name": "all tests pass",
+        "description": "it appears that some tests failed in the product submission",
+        "steps": [
+          {
+            "name": "the tests pass and are successful",
+            "result": {
+              "status": "failed",
+              "error_message": "it appears that there are failures in some tests"
+            }
+          }
+        ]
+      },
+      {
+        "name": "there is only one commit",
+        "description": "it appears that there are multiple commits",
+        "steps": [
+          {
+            "name": "there is only one commit",
+            "result": {
+              "status": "failed",
+              "error_message": "it appears that the PR has more than one commit"
+            }
+          }
+        ]
+      },
+      {
+        "name": "PR title is not empty",
+        "description": "it appears that the title is empty",
+        "steps": [
+          {
+            "name": "the PR title is not empty",
+            "result": {
+              "status": "failed",
+              "error_message": "it appears that the title is empty"
+            }
+          }
+        ]
+      }
+    ]
+  }
+]`)
+	comment, _, state, err := prSuite.GetLabelsAndCommentsFromSuiteResultsBuffer()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if state != "failure" {
+		t.Fatalf("error: state (%v) doesn't match expected state (failure)", state)
+	}
+	if !strings.Contains(comment, "0 of 3 requirements have passed") {
+		t.Fatalf("error: the comment must count all failures, including elided ones; got '%v'", comment)
+	}
+	// each rendered failure names its scenario alongside the description
+	if !strings.Contains(comment, "- [FAIL] it appears that some tests failed in the product submission (all tests pass)") {
+		t.Fatalf("error: the comment must name the failing scenario; got '%v'", comment)
+	}
+	if !strings.Contains(comment, "- [FAIL] it appears that there are multiple commits (there is only one commit)") {
+		t.Fatalf("error: the comment must name the failing scenario; got '%v'", comment)
+	}
+	if !strings.Contains(comment, "remediation: re-run the conformance suite until no tests fail") {
+		t.Fatalf("error: the comment must include the remediation hint; got '%v'", comment)
+	}
+	if !strings.Contains(comment, "remediation: squash the pull request down to a single commit") {
+		t.Fatalf("error: the comment must include the remediation hint; got '%v'", comment)
+	}
+	// failures beyond the cap are summarized rather than rendered
+	if strings.Contains(comment, "it appears that the title is empty") {
+		t.Fatalf("error: failures beyond the cap must be elided; got '%v'", comment)
+	}
+	if !strings.Contains(comment, "…and 1 more") {
+		t.Fatalf("error: the comment must summarize the elided failures; got '%v'", comment)
+	}
+}
+
+func TestNormalizeLogContent(t *testing.T) {
+	type testCase struct {
+		Name            string
+		Content         string
+		ExpectedContent string
+		ExpectedChanged bool
+	}
+	for _, tc := range []testCase{
+		{
+			Name:            "plain log",
+			Content:         "Ran 406 of 7201 Specs\nSUCCESS! -- 406 Passed | 0 Failed\n",
+			ExpectedContent: "Ran 406 of 7201 Specs\nSUCCESS! -- 406 Passed | 0 Failed\n",
+		},
+		{
+			Name:            "ansi colored log",
+			Content:         "\x1b[32mServer Version: v1.30\x1b[0m\r\nRan 406 of 7201 Specs\n",
+			ExpectedContent: "Server Version: v1.30\nRan 406 of 7201 Specs\n",
+			ExpectedChanged: true,
+		},
+		{
+			Name:            "log with embedded NULs",
+			Content:         "Server\x00 Version: v1.30\nRan 406\x00 of 7201 Specs\n",
+			ExpectedContent: "Server Version: v1.30\nRan 406 of 7201 Specs\n",
+			ExpectedChanged: true,
+		},
+		{
+			Name:            "log with invalid UTF-8",
+			Content:         "Server Version: v1.30\xff\xfe\n",
+			ExpectedContent: "Server Version: v1.30\n",
+			ExpectedChanged: true,
+		},
+	} {
+		cleaned, changed := NormalizeLogContent(tc.Content)
+		if cleaned != tc.ExpectedContent {
+			t.Fatalf("error with testcase (%v): content = %q, want %q", tc.Name, cleaned, tc.ExpectedContent)
+		}
+		if changed != tc.ExpectedChanged {
+			t.Fatalf("error with testcase (%v): changed = %v, want %v", tc.Name, changed, tc.ExpectedChanged)
+		}
+	}
+}
+
+func TestGetLabelsAndCommentsFromSuiteResultsBufferAdvisory(t *testing.T) {
+	defaultAdvisoryRequirements := advisoryRequirements
+	defer func() {
+		advisoryRequirements = defaultAdvisoryRequirements
+	}()
+	buffer := `[
+  {
+    "name": "verify conformance product submission PR",
+    "elements": [
+      {
+        "name": "all tests pass",
+        "description": "it appears that some tests failed in the product submission",
+        "steps": [
+          {
+            "name": "the tests pass and are successful",
+            "result": {
+              "status": "failed",
+              "error_message": "it appears that there are failures in some tests"
+            }
+          }
+        ]
+      },
+      {
+        "name": "the product logo is a valid image",
+        "description": "the product logo does not appear to be a valid image",
+        "steps": [
+          {
+            "name": "the product logo is an SVG image",
+            "result": {
+              "status": "failed",
+              "error_message": "the logo is wider than expected"
+            }
+          }
+        ]
+      }
+    ]
+  }
+]`
+	type testCase struct {
+		name          string
+		advisory      []string
+		expectedState string
+	}
+	for _, tc := range []testCase{
+		{
+			name:          "a blocking and an advisory failure",
+			advisory:      []string{"the product logo is a valid image"},
+			expectedState: "failure",
+		},
+		{
+			name:          "only advisory failures",
+			advisory:      []string{"all tests pass", "the product logo is a valid image"},
+			expectedState: "success",
+		},
+		{
+			name:          "no advisory configuration",
+			advisory:      []string{},
+			expectedState: "failure",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			SetAdvisoryRequirements(tc.advisory)
+			prSuite := NewPRSuite(&PullRequest{})
+			prSuite.KubernetesReleaseVersion = "v1.30"
+			prSuite.KubernetesReleaseVersionLatest = "v1.30"
+			prSuite.buffer = *bytes.NewBufferString(buffer)
+			comment, _, state, err := prSuite.GetLabelsAndCommentsFromSuiteResultsBuffer()
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if state != tc.expectedState {
+				t.Fatalf("error: state (%v) doesn't match expected state (%v)", state, tc.expectedState)
+			}
+			if strings.Contains(comment, "- [WARN] it appears that some tests failed in the product submission") != (len(tc.advisory) == 2) {
+				t.Fatalf("error: unexpected severity rendering in comment: %v", comment)
+			}
+			if len(tc.advisory) > 0 && !strings.Contains(comment, "- [WARN] the product logo does not appear to be a valid image") {
+				t.Fatalf("error: the advisory failure must be rendered as a warning; got %v", comment)
+			}
+		})
+	}
+}
+
+func TestGetLabelsAndCommentsFromSuiteResultsBufferAdvisoryOverride(t *testing.T) {
+	defaultAdvisoryRequirements := advisoryRequirements
+	defer func() {
+		advisoryRequirements = defaultAdvisoryRequirements
+	}()
+	SetAdvisoryRequirements([]string{"the product logo is a valid image"})
+	buffer := `[
+  {
+    "name": "verify conformance product submission PR",
+    "elements": [
+      {
+        "name": "the product logo is a valid image",
+        "description": "the product logo does not appear to be a valid image",
+        "steps": [
+          {
+            "name": "the product logo is an SVG image",
+            "result": {
+              "status": "failed",
+              "error_message": "the logo is wider than expected"
+            }
+          }
+        ]
+      }
+    ]
+  }
+]`
+	type testCase struct {
+		name            string
+		labels          []string
+		expectedWarning bool
+	}
+	for _, tc := range []testCase{
+		{
+			name:            "without an override the advisory is rendered as a warning",
+			labels:          []string{},
+			expectedWarning: true,
+		},
+		{
+			name:            "an overridden advisory is treated as satisfied",
+			labels:          []string{AdvisoryOverrideLabel("the product logo is a valid image")},
+			expectedWarning: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			prSuite := NewPRSuite(&PullRequest{
+				Labels: tc.labels,
+			})
+			prSuite.KubernetesReleaseVersion = "v1.30"
+			prSuite.KubernetesReleaseVersionLatest = "v1.30"
+			prSuite.buffer = *bytes.NewBufferString(buffer)
+			comment, _, state, err := prSuite.GetLabelsAndCommentsFromSuiteResultsBuffer()
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if state != "success" {
+				t.Fatalf("error: state (%v) doesn't match expected state (success)", state)
+			}
+			if strings.Contains(comment, "- [WARN] the product logo does not appear to be a valid image") != tc.expectedWarning {
+				t.Fatalf("error: unexpected warning rendering in comment: %v", comment)
+			}
+		})
+	}
+}
+
+func TestGetRequirementCountsFromSuiteResultsBuffer(t *testing.T) {
+	prSuite := NewPRSuite(&PullRequest{})
+	prSuite.buffer = *bytes.NewBufferString(`[
+  {
+    "name": "verify conformance product submission PR",
+    "elements": [
+      {
+        "name": "all tests pass",
+        "description": "it appears that some tests failed in the product submission",
+        "steps": [
+          {
+            "name": "the tests pass and are successful",
+            "result": {
+              "status": "failed",
+              "error_message": "it appears that there are failures in some tests"
+            }
+          }
+        ]
+      },
+      {
+        "name": "there is only one commit",
+        "steps": [
+          {
+            "name": "there is only one commit",
+            "result": {
+              "status": "passed"
+            }
+          }
+        ]
+      }
+    ]
+  }
+]`)
+	total, failed, err := prSuite.GetRequirementCountsFromSuiteResultsBuffer()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if total != 2 {
+		t.Fatalf("error: expected 2 requirements to have run; got %v", total)
+	}
+	if failed != 1 {
+		t.Fatalf("error: expected 1 requirement to have failed; got %v", failed)
+	}
+}
+
 func TestInitializeScenario(t *testing.T) {
 	prSuite := NewPRSuite(&PullRequest{})
 	prSuite.NewTestSuite(PRSuiteOptions{Paths: []string{"../../kodata/features/verify-conformance.feature"}})
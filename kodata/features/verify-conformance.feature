@@ -1,11 +1,13 @@
 Feature: verify conformance product submission PR
 
+  @title
   Scenario: PR title is not empty
     it seems that there is no title set
 
     Given a PR title
     Then the PR title is not empty
 
+  @files
   Scenario: submission contains all required files
     there seems to be some required files missing (https://github.com/cncf/k8s-conformance/blob/master/instructions.md#contents-of-the-pr)
 
@@ -20,10 +22,12 @@ Feature: verify conformance product submission PR
       | "e2e.log"      | "text"     |
       | "junit_01.xml" | "xml"      |
 
+  @files
   Scenario: submission only contains required files
     Given the files in the PR
     Then the files included in the PR are only: "README.md, PRODUCT.yaml, e2e.log, junit_01.xml"
 
+  @files
   Scenario: submission has files in structure of releaseversion/productname/
     the submission file directory does not seem to match the Kubernetes release version in the files
 
@@ -33,12 +37,22 @@ Feature: verify conformance product submission PR
     # $2 is the product name
     # example: v1.23/coolthing
 
+  @files
   Scenario: submission is only one product
     the submission seems to contain files of multiple Kubernetes release versions or products. Each Kubernetes release version and products should be submitted in a separate PRs
 
     Given the files in the PR
     Then there is only one path of folders
 
+  @files
+  Scenario: the submission directory structure is valid
+    the submission files don't appear to be placed in a single [KubernetesReleaseVersion]/[ProductName] directory matching the release version in the title
+
+    Given the files in the PR
+    And the title of the PR
+    Then the submission directory structure is valid
+
+  @title
   Scenario: submission release version in title matches release version in folder structure
     the title of the submission does not seem to contain a Kubernetes release version that matches the release version in the submitted files
 
@@ -46,6 +60,15 @@ Feature: verify conformance product submission PR
     And the title of the PR
     Then the release version matches the release version in the title
 
+  @title
+  Scenario: the version in the PRODUCT.yaml matches the release version of the submission
+    the Kubernetes release version named in the title, the submission directory, and the version field of the PRODUCT.yaml do not seem to agree
+
+    Given the files in the PR
+    And the title of the PR
+    Then the versions in the title, directory structure, and PRODUCT.yaml agree
+
+  @product-yaml
   Scenario: the PRODUCT.yaml metadata contains all required fields
     it appears that the PRODUCT.yaml file does not contain all the required fields (https://github.com/cncf/k8s-conformance/blob/master/instructions.md#productyaml)
 
@@ -63,6 +86,7 @@ Feature: verify conformance product submission PR
       | "documentation_url"     |
       | "contact_email_address" |
 
+  @product-yaml
   Scenario: the URL and email fields in the PRODUCT.yaml are valid
     it appears that field(s) in the PRODUCT.yaml aren't correctly formatted
 
@@ -77,6 +101,7 @@ Feature: verify conformance product submission PR
       | "product_logo_url"      | "URL"       |
       | "contact_email_address" | "email"     |
 
+  @product-yaml
   Scenario: the URL fields in the PRODUCT.yaml resolve to their specified data types
     it appears that URL(s) in the PRODUCT.yaml don't resolve to the correct data type
 
@@ -89,12 +114,21 @@ Feature: verify conformance product submission PR
       | "repo_url"          | "text/html"                        |
       | "documentation_url" | "text/html"                        |
 
+  @product-yaml
+  Scenario: the product logo is an SVG image
+    it appears that the product logo in the PRODUCT.yaml doesn't resolve to an SVG image
+
+    Given a "PRODUCT.yaml" file
+    Then the product logo is an SVG image
+
+  @product-yaml
   Scenario: the type field in PRODUCT.yaml is valid
     it appears that the type field does not match either "distribution", "hosted platform" or "installer"
 
     Given a "PRODUCT.yaml" file
     Then the field "type" matches one of the following values: distribution, hosted platform, installer
 
+  @title
   Scenario: title of product submission contains Kubernetes release version and product name
     the submission title is missing either a Kubernetes release version (v1.xx) or product name
 
@@ -105,6 +139,22 @@ Feature: verify conformance product submission PR
     # $3 is the product name
     # example: Conformance test for v1.23 Cool Engine
 
+  @artifacts
+  Scenario: artifact versions match the release version of the submission
+    the Kubernetes release version found in the submitted artifacts does not seem to match the release version of the submission
+
+    Given the files in the PR
+    And the title of the PR
+    Then the release version in the artifacts matches the release version of the submission
+
+  @artifacts
+  Scenario: the e2e.log contains a Sonobuoy run summary
+    it appears that the e2e.log does not contain the summary of a Sonobuoy run
+
+    Given a "e2e.log" file
+    Then the e2e.log contains a Sonobuoy run summary
+
+  @release
   Scenario: the submission release version is a supported version of Kubernetes
     the Kubernetes release version in this pull request does not qualify for conformance submission anymore (https://github.com/cncf/k8s-conformance/blob/master/terms-conditions/Certified_Kubernetes_Terms.md#qualifying-offerings-and-self-testing)
 
@@ -112,12 +162,21 @@ Feature: verify conformance product submission PR
     And the files in the PR
     Then it is a valid and supported release
 
+  @tests
+  Scenario: the junit_01.xml is well-formed and contains test results
+    it appears that the junit_01.xml does not contain any test results
+
+    Given a "junit_01.xml" file
+    Then the junit_01.xml is well-formed and contains test results
+
+  @tests
   Scenario: all required conformance tests in the junit_01.xml are present
     it appears that some tests are missing from the product submission
 
     Given a "junit_01.xml" file
     Then all required tests in junit_01.xml are present
 
+  @tests
   Scenario: all tests pass
     it appears that some tests failed in the product submission
 
@@ -125,6 +184,7 @@ Feature: verify conformance product submission PR
     Then the tests pass and are successful
     And all required tests are present
 
+  @commits
   Scenario: there is only one commit
     it appears that there is not exactly one commit. Please rebase and squash with `git rebase -i HEAD` (https://git-scm.com/docs/git-rebase)
 
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -31,7 +32,9 @@ import (
 	"k8s.io/test-infra/prow/github"
 	"k8s.io/test-infra/prow/plugins"
 
+	"sigs.k8s.io/verify-conformance/internal/metrics"
 	"sigs.k8s.io/verify-conformance/internal/plugin"
+	"sigs.k8s.io/verify-conformance/internal/suite"
 )
 
 const (
@@ -41,14 +44,47 @@ const (
 type options struct {
 	port int
 
-	repo            string
-	prEventJSONPath string
-	dryRun          bool
-	github          prowflagutil.GitHubOptions
+	repo                    string
+	prEventJSONPath         string
+	dryRun                  bool
+	insecureSkipTLSVerify   bool
+	checksRun               bool
+	attachJSONResults       bool
+	jsonLogging             bool
+	acceptReleaseCandidates bool
+	retainCommentHistory    bool
+	supportedReleases       int
+	github                  prowflagutil.GitHubOptions
 
-	updatePeriod time.Duration
+	advisoryRequirements  string
+	extraRequiredFiles    string
+	managedLabels         string
+	versionLabelTemplates string
+	fileLabelTemplates    string
+	titlePattern          string
+	commentFooter         string
+	successComment        string
+	featureTags           string
+	requiredAck           string
+	expectedBaseBranch    string
+	urlAllowedHosts       string
+	urlDeniedHosts        string
+	urlCABundlePath       string
+	outboundProxyURL      string
+	graphqlEndpoint       string
+	orgOverridesPath      string
+
+	updatePeriod       time.Duration
+	scanTimeout        time.Duration
+	staleSubmissionAge time.Duration
+	concurrency        int
+	rateLimitThreshold int
+	urlCheckTimeout    time.Duration
+	maxFileSizeBytes   int64
+	prStagger          time.Duration
 
 	webhookSecretFile string
+	verifyTokenFile   string
 }
 
 func (o *options) Validate() error {
@@ -75,8 +111,40 @@ func gatherOptions() options {
 	fs.StringVar(&o.repo, "repo", "", "GitHub repo to use (i.e: 'cncf/k8s-conformance' or 'cncf-infra/k8s-conformance').")
 	fs.StringVar(&o.prEventJSONPath, "pr-event-json-path", "", "path to a GitHub workflow event.json file")
 	fs.BoolVar(&o.dryRun, "dry-run", true, "Dry run for testing. Uses API tokens but does not mutate.")
+	fs.BoolVar(&o.insecureSkipTLSVerify, "product-yaml-url-insecure-skip-tls-verify", false, "Skip TLS certificate verification when resolving PRODUCT.yaml URL fields. Intended for testing only.")
+	fs.BoolVar(&o.checksRun, "checks-run", false, "Report results as a GitHub Checks run with per-requirement annotations. Requires the checks:write permission on the GitHub App.")
+	fs.BoolVar(&o.attachJSONResults, "attach-json-results", false, "Attach a machine-readable JSON summary of the suite results to the comment in a collapsed details block.")
+	fs.BoolVar(&o.jsonLogging, "json-logging", true, "Output logs in JSON format for log aggregation.")
+	fs.BoolVar(&o.acceptReleaseCandidates, "accept-release-candidates", false, "Accept submissions referencing a release candidate or other pre-release version.")
+	fs.IntVar(&o.supportedReleases, "supported-releases", 2, "Number of minor versions behind the latest Kubernetes release that still qualify for conformance submission.")
+	fs.StringVar(&o.advisoryRequirements, "advisory-requirements", "", "Comma-separated list of requirement names whose failures are advisory; they appear in the comment as warnings without failing the status.")
+	fs.StringVar(&o.extraRequiredFiles, "extra-required-files", "", "Comma-separated list of additional file names, or glob patterns over file names, that every submission must include, e.g. 'signed-eula.pdf'. Absent ones are labelled missing-file-<name>.")
+	fs.StringVar(&o.managedLabels, "managed-labels", "", "Comma-separated list of label names the plugin manages on PRs, overriding the defaults.")
+	fs.StringVar(&o.versionLabelTemplates, "version-label-templates", "", "Comma-separated list of label templates containing a '%v' placeholder for the release version, overriding the defaults.")
+	fs.StringVar(&o.fileLabelTemplates, "file-label-templates", "", "Comma-separated list of label templates containing a '%v' placeholder for a missing file name, overriding the defaults.")
+	fs.StringVar(&o.commentFooter, "comment-footer", "", "Footer appended below a horizontal rule to every comment the plugin posts, e.g. maintainer contact details or documentation links.")
+	fs.StringVar(&o.featureTags, "feature-tags", "", "Godog tag expression limiting suite runs to matching scenarios, e.g. '@product-yaml'. Also read from VERIFY_CONFORMANCE_FEATURE_TAGS. Empty runs every scenario.")
+	fs.StringVar(&o.successComment, "success-comment", "", "Message appended to the result comment when every requirement passes, e.g. congratulating the submitter and outlining the review and merge steps that follow.")
+	fs.BoolVar(&o.retainCommentHistory, "retain-comment-history", false, "Keep earlier bot comments when a new result is posted, instead of pruning them so only the latest result remains.")
+	fs.StringVar(&o.requiredAck, "required-acknowledgment", "", "Phrase the PR description must contain before checks run, e.g. an agreement to the conformance terms. Matched case-insensitively; empty disables the check.")
+	fs.StringVar(&o.expectedBaseBranch, "expected-base-branch", "", "Base branch submissions must target, e.g. 'master'. PRs opened against another branch receive an advisory comment and the wrong-base-branch label. Empty disables the check.")
+	fs.StringVar(&o.titlePattern, "conformance-title-pattern", "", "Regular expression used to recognise conformance submission titles, overriding the default. Named groups 'version' and 'product' are extracted when present.")
 	fs.DurationVar(&o.updatePeriod, "update-period", time.Hour*24, "Period duration for periodic scans of all PRs.")
+	fs.DurationVar(&o.scanTimeout, "scan-timeout", time.Hour, "Deadline for a single periodic scan of all PRs; PRs not handled in time are skipped until the next scan.")
+	fs.DurationVar(&o.staleSubmissionAge, "stale-submission-age", time.Hour*24*14, "How long a submission may keep failing its checks before a single reminder comment is posted.")
+	fs.IntVar(&o.concurrency, "concurrency", 4, "Maximum number of PRs processed in parallel during periodic scans.")
+	fs.DurationVar(&o.prStagger, "pr-stagger", 0, "Delay inserted between dispatching PRs during periodic scans and between PRODUCT.yaml URL checks, to avoid tripping secondary rate limits. Zero disables staggering.")
+	fs.IntVar(&o.rateLimitThreshold, "search-rate-limit-threshold", 100, "Pause search pagination until the GraphQL rate limit resets when fewer points than this remain.")
+	fs.Int64Var(&o.maxFileSizeBytes, "max-file-size-bytes", 250*1024*1024, "Largest file size fetched and held in memory when checking a submission. Larger files are labelled oversized and their contents are not checked.")
+	fs.DurationVar(&o.urlCheckTimeout, "product-yaml-url-check-timeout", time.Second*10, "Timeout for resolving PRODUCT.yaml URL fields.")
+	fs.StringVar(&o.urlAllowedHosts, "product-yaml-url-allowed-hosts", "", "Comma-separated list of hosts PRODUCT.yaml URL fields may name; when set, all other hosts are refused. Hosts resolving to loopback, private, or link-local addresses are always refused unless listed here.")
+	fs.StringVar(&o.urlDeniedHosts, "product-yaml-url-denied-hosts", "", "Comma-separated list of hosts PRODUCT.yaml URL fields must never name.")
+	fs.StringVar(&o.urlCABundlePath, "product-yaml-url-ca-bundle", "", "Path to a PEM bundle of additional certificate authorities trusted when verifying PRODUCT.yaml URL fields, layered on top of the system roots.")
+	fs.StringVar(&o.outboundProxyURL, "outbound-proxy-url", "", "Proxy URL for outbound fetches, overriding HTTP_PROXY/HTTPS_PROXY from the environment.")
+	fs.StringVar(&o.orgOverridesPath, "org-overrides", "", "Path to a YAML or JSON file mapping org logins to per-org overrides of the comment footer, success comment, feature tags, and supported releases.")
+	fs.StringVar(&o.graphqlEndpoint, "github-graphql-endpoint", "", "Base URL of the GitHub GraphQL API, e.g. 'https://github.example.com/api/graphql' for an enterprise deployment. Repos configured with a matching host prefix are scoped to the org that follows it. Empty assumes the public API.")
 	fs.StringVar(&o.webhookSecretFile, "hmac-secret-file", "/etc/webhook/hmac", "Path to the file containing the GitHub HMAC secret.")
+	fs.StringVar(&o.verifyTokenFile, "verify-token-file", "", "Path to the file containing the shared-secret token for the manual verify endpoint. The endpoint is only registered when set.")
 
 	for _, group := range []flagutil.OptionGroup{&o.github} {
 		group.AddFlags(fs)
@@ -87,16 +155,83 @@ func gatherOptions() options {
 	return o
 }
 
+// splitCommaList splits a comma-separated flag value into its non-empty,
+// whitespace-trimmed entries.
+func splitCommaList(input string) []string {
+	entries := []string{}
+	for _, entry := range strings.Split(input, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
 func main() {
 	o := gatherOptions()
 	if err := o.Validate(); err != nil {
 		logrus.Fatalf("Invalid options: %v", err)
 	}
 
-	logrus.SetFormatter(&logrus.JSONFormatter{})
+	if o.jsonLogging {
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	} else {
+		logrus.SetFormatter(&logrus.TextFormatter{})
+	}
 	logrus.SetLevel(logrus.InfoLevel)
 	log := logrus.StandardLogger().WithField("plugin", pluginName)
 
+	if err := plugin.SetManagedLabels(splitCommaList(o.managedLabels), splitCommaList(o.versionLabelTemplates), splitCommaList(o.fileLabelTemplates)); err != nil {
+		logrus.Fatalf("Invalid label configuration: %v", err)
+	}
+	if err := plugin.SetConformancePRTitlePattern(o.titlePattern); err != nil {
+		logrus.Fatalf("Invalid title pattern: %v", err)
+	}
+	plugin.SetCommentFooter(o.commentFooter)
+	plugin.SetSuccessComment(o.successComment)
+	plugin.SetRetainCommentHistory(o.retainCommentHistory)
+	plugin.SetRequiredAcknowledgment(o.requiredAck)
+	plugin.SetExpectedBaseBranch(o.expectedBaseBranch)
+	plugin.SetFeatureTagFilter(o.featureTags)
+	plugin.SetDryRun(o.dryRun)
+	plugin.SetProductYAMLURLChecksInsecureSkipTLSVerify(o.insecureSkipTLSVerify)
+	plugin.SetChecksRunEnabled(o.checksRun)
+	plugin.SetAttachJSONResults(o.attachJSONResults)
+	suite.SetAcceptReleaseCandidates(o.acceptReleaseCandidates)
+	suite.SetAdvisoryRequirements(splitCommaList(o.advisoryRequirements))
+	if err := suite.SetExtraRequiredFiles(splitCommaList(o.extraRequiredFiles)); err != nil {
+		logrus.Fatalf("Invalid extra required files configuration: %v", err)
+	}
+	suite.SetLastSupportingVersions(o.supportedReleases)
+	plugin.SetHandleAllConcurrency(o.concurrency)
+	plugin.SetHandleAllStagger(o.prStagger)
+	plugin.SetHandleAllTimeout(o.scanTimeout)
+	plugin.SetStaleSubmissionAge(o.staleSubmissionAge)
+	plugin.SetSearchRateLimitThreshold(o.rateLimitThreshold)
+	plugin.SetMaxFileSizeBytes(o.maxFileSizeBytes)
+	plugin.SetProductYAMLURLCheckTimeout(o.urlCheckTimeout)
+	plugin.SetProductYAMLURLHostPolicy(splitCommaList(o.urlAllowedHosts), splitCommaList(o.urlDeniedHosts))
+	if err := plugin.SetProductYAMLURLCABundle(o.urlCABundlePath); err != nil {
+		logrus.Fatalf("Invalid CA bundle configuration: %v", err)
+	}
+	if err := plugin.SetOutboundProxyURL(o.outboundProxyURL); err != nil {
+		logrus.Fatalf("Invalid proxy configuration: %v", err)
+	}
+	if err := plugin.SetGitHubGraphQLEndpoint(o.graphqlEndpoint); err != nil {
+		logrus.Fatalf("Invalid GraphQL endpoint configuration: %v", err)
+	}
+	if err := plugin.SetOrgOverridesFromFile(o.orgOverridesPath); err != nil {
+		logrus.Fatalf("Invalid org overrides configuration: %v", err)
+	}
+
+	go func() {
+		http.Handle("/metrics", metrics.Handler())
+		http.Handle("/healthz", plugin.NewHealthzHandler(log))
+		logrus.WithError(http.ListenAndServe(fmt.Sprintf(":%v", o.port), nil)).Error("error serving metrics")
+	}()
+
 	secrets := []string{}
 	if o.github.TokenPath != "" {
 		secrets = append(secrets, o.github.TokenPath)
@@ -107,6 +242,9 @@ func main() {
 	if o.webhookSecretFile != "" {
 		secrets = append(secrets, o.webhookSecretFile)
 	}
+	if o.verifyTokenFile != "" {
+		secrets = append(secrets, o.verifyTokenFile)
+	}
 	if err := secret.Add(secrets...); err != nil {
 		logrus.WithError(err).Fatal("Error starting test-infra/prow/config/secret agent.")
 	}
@@ -118,6 +256,9 @@ func main() {
 	if err := githubClient.Throttle(360, 360); err != nil {
 		logrus.WithError(err).Fatal("error: throttling GitHub client")
 	}
+	if o.verifyTokenFile != "" {
+		http.Handle("/verify", plugin.NewVerifyHandler(log, githubClient, secret.GetTokenGenerator(o.verifyTokenFile)))
+	}
 	if file := o.prEventJSONPath; file != "" {
 		_, err := os.Stat(o.prEventJSONPath)
 		if err == nil {